@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/mattfenwick/upanddowntheriver/pkg/game"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"os"
+	"strings"
+	"time"
+)
+
+const requestTimeout = 15 * time.Second
+
+const usage = `usage: admin <config> <command>
+commands:
+  games                    list the games being served
+  dump <game>              dump a game's internal state, everyone's cards included
+  finish-round <game>      finish the current round, abandoning it if it isn't over yet
+  remove <game> <player>   remove a player, even in the middle of a round
+  reset <game>             start a game over with nobody in it
+  broadcast <message>      show a message at every table; an empty message clears it`
+
+type Config struct {
+	LogLevel   string
+	Host       string
+	Port       int
+	AdminToken string
+}
+
+// GetLogLevel ...
+func (config *Config) GetLogLevel() (log.Level, error) {
+	return log.ParseLevel(config.LogLevel)
+}
+
+// GetConfig ...
+func GetConfig(configPath string) (*Config, error) {
+	var config *Config
+
+	viper.SetConfigFile(configPath)
+	err := viper.ReadInConfig()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to ReadInConfig at %s", configPath)
+	}
+	// keep the token out of config files
+	err = viper.BindEnv("AdminToken", "ADMIN_TOKEN")
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to bind ADMIN_TOKEN")
+	}
+
+	err = viper.Unmarshal(&config)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal config at %s", configPath)
+	}
+
+	return config, nil
+}
+
+func doOrDie(err error) {
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+}
+
+func printJson(value interface{}) error {
+	bytes, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return errors.Wrapf(err, "unable to marshal json")
+	}
+	fmt.Println(string(bytes))
+	return nil
+}
+
+// run carries out one admin command
+func run(ctx context.Context, client *game.Client, command string, args []string) error {
+	argCount := map[string]int{"games": 0, "dump": 1, "finish-round": 1, "remove": 2, "reset": 1}
+	if count, ok := argCount[command]; ok && len(args) != count {
+		return errors.Errorf("%s takes %d argument(s)\n%s", command, count, usage)
+	}
+	switch command {
+	case "games":
+		list, err := client.ListGames(ctx)
+		if err != nil {
+			return err
+		}
+		return printJson(list)
+	case "dump":
+		model, err := client.DumpGame(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		fmt.Println(model)
+		return nil
+	case "finish-round":
+		adminGame, err := client.ForceFinishRound(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		return printJson(adminGame)
+	case "remove":
+		adminGame, err := client.ForceRemovePlayer(ctx, args[0], args[1])
+		if err != nil {
+			return err
+		}
+		return printJson(adminGame)
+	case "reset":
+		adminGame, err := client.ResetGame(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		return printJson(adminGame)
+	case "broadcast":
+		list, err := client.Broadcast(ctx, strings.Join(args, " "))
+		if err != nil {
+			return err
+		}
+		return printJson(list)
+	default:
+		return errors.Errorf("unrecognized command %q\n%s", command, usage)
+	}
+}
+
+func main() {
+	if len(os.Args) < 3 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+	configPath := os.Args[1]
+	config, err := GetConfig(configPath)
+	doOrDie(err)
+
+	logLevel, err := config.GetLogLevel()
+	doOrDie(err)
+
+	log.SetLevel(logLevel)
+
+	client := game.NewClient(config.Host, config.Port)
+	client.AdminToken = config.AdminToken
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	doOrDie(run(ctx, client, os.Args[2], os.Args[3:]))
+}
@@ -1,7 +1,7 @@
 package main
 
 import (
-	"encoding/json"
+	"context"
 	"github.com/mattfenwick/upanddowntheriver/pkg/game"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
@@ -55,17 +55,8 @@ func main() {
 	log.SetLevel(logLevel)
 
 	client := game.NewClient(config.Host, config.Port)
-	log.Infof("client: %+v", client)
+	log.Debugf("client: %+v", client)
 
-	mod, err := client.GetModel()
-	doOrDie(err)
-	//modBytes, err := json.MarshalIndent(mod, "", "  ")
-	//doOrDie(err)
-	log.Infof("model: \n%s\n\n\n", mod)
-
-	myModel, err := client.GetMyModel("abc")
-	doOrDie(err)
-	myModelBytes, err := json.MarshalIndent(myModel, "", "  ")
-	doOrDie(err)
-	log.Infof("my model: \n%s\n", string(myModelBytes))
+	cli := NewCli(client, os.Stdin, os.Stdout)
+	doOrDie(cli.Run(context.Background()))
 }
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"github.com/mattfenwick/upanddowntheriver/pkg/game"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	pollInterval   = time.Second
+	requestTimeout = 15 * time.Second
+)
+
+const helpText = `commands:
+  join <name>          join the game
+  show                 show the table and my hand
+  deck <type>          set the deck type: Standard or DoubleStandard
+  cards <count>        set the number of cards per player
+  start                start a round
+  wager <hands>        wager on how many hands I'll win
+  play <card>          play a card, such as QH or 10S -- or by its number in the prompt
+  finish               finish the round, once every card has been played
+  endgame              finish the game, archiving its scores
+  remove <player>      remove a player
+  help                 show this message
+  quit                 leave`
+
+// Cli is a line-based client: it reads commands from in, and writes the game -- as it
+// changes -- to out.
+type Cli struct {
+	Client *game.Client
+	In     io.Reader
+	Out    io.Writer
+	// mutex guards everything below, and writes to Out
+	mutex sync.Mutex
+	me    string
+	model *game.PlayerModel
+}
+
+func NewCli(client *game.Client, in io.Reader, out io.Writer) *Cli {
+	return &Cli{Client: client, In: in, Out: out}
+}
+
+func (cli *Cli) printf(format string, args ...interface{}) {
+	cli.mutex.Lock()
+	defer cli.mutex.Unlock()
+	fmt.Fprintf(cli.Out, format, args...)
+}
+
+// Run reads commands until in is closed or the player quits, and shows updates as they arrive
+func (cli *Cli) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go cli.poll(ctx)
+
+	cli.printf("%s\n", helpText)
+	scanner := bufio.NewScanner(cli.In)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if fields[0] == "quit" {
+			return nil
+		}
+		cli.execute(ctx, fields[0], fields[1:])
+	}
+	return scanner.Err()
+}
+
+// poll fetches the model regularly, so that other players' actions show up
+func (cli *Cli) poll(ctx context.Context) {
+	for {
+		cli.mutex.Lock()
+		me := cli.me
+		cli.mutex.Unlock()
+		requestCtx, cancel := context.WithTimeout(ctx, requestTimeout)
+		pm, err := cli.Client.GetMyModel(requestCtx, me)
+		cancel()
+		if err == nil {
+			cli.update(pm, false)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// update shows pm if it's new, or if force is set
+func (cli *Cli) update(pm *game.PlayerModel, force bool) {
+	cli.mutex.Lock()
+	defer cli.mutex.Unlock()
+	if pm.Me != cli.me {
+		// a stale response from before joining, or from another name
+		return
+	}
+	if !force && cli.model != nil && cli.model.Version == pm.Version {
+		return
+	}
+	cli.model = pm
+	fmt.Fprintf(cli.Out, "\n%s\n%s\n> ", renderModel(pm), renderPrompt(pm))
+}
+
+func (cli *Cli) current() (string, *game.PlayerModel) {
+	cli.mutex.Lock()
+	defer cli.mutex.Unlock()
+	return cli.me, cli.model
+}
+
+func (cli *Cli) execute(ctx context.Context, command string, args []string) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+	me, model := cli.current()
+
+	var pm *game.PlayerModel
+	var err error
+	switch command {
+	case "help":
+		cli.printf("%s\n> ", helpText)
+		return
+	case "show":
+		pm, err = cli.Client.GetMyModel(ctx, me)
+	case "join":
+		if len(args) != 1 {
+			err = fmt.Errorf("usage: join <name>")
+			break
+		}
+		pm, err = cli.Client.Join(ctx, args[0])
+		if err == nil {
+			cli.mutex.Lock()
+			cli.me = pm.Me
+			cli.mutex.Unlock()
+		}
+	case "deck":
+		var deckType game.DeckType
+		if len(args) != 1 {
+			err = fmt.Errorf("usage: deck <type>")
+		} else if err = deckType.UnmarshalText([]byte(args[0])); err == nil {
+			pm, err = cli.Client.SetDeckType(ctx, me, deckType)
+		}
+	case "cards":
+		var count int
+		if count, err = intArg(args, "cards <count>"); err == nil {
+			pm, err = cli.Client.SetCardsPerPlayer(ctx, me, count)
+		}
+	case "start":
+		pm, err = cli.Client.StartRound(ctx, me)
+	case "wager":
+		var hands int
+		if hands, err = intArg(args, "wager <hands>"); err == nil {
+			pm, err = cli.atVersion(model).MakeWager(ctx, me, hands)
+		}
+	case "play":
+		var card *game.Card
+		if card, err = cardArg(args, model); err == nil {
+			pm, err = cli.atVersion(model).PlayCard(ctx, me, card)
+		}
+	case "finish":
+		pm, err = cli.Client.FinishRound(ctx, me)
+	case "endgame":
+		pm, err = cli.Client.FinishGame(ctx, me)
+	case "remove":
+		if len(args) != 1 {
+			err = fmt.Errorf("usage: remove <player>")
+			break
+		}
+		pm, err = cli.Client.RemovePlayer(ctx, me, args[0])
+	default:
+		err = fmt.Errorf("unrecognized command %s; try help", command)
+	}
+
+	if current, ok := game.ConflictPlayerModel(err); ok {
+		cli.printf("the game changed before that got there, so it wasn't applied\n")
+		cli.update(current, true)
+		return
+	}
+	if err != nil {
+		cli.printf("error: %s\n> ", err.Error())
+		return
+	}
+	cli.update(pm, true)
+}
+
+// atVersion makes an action conditional on the game not having changed since the model the
+// player decided on, so that nothing is wagered or played from a stale view
+func (cli *Cli) atVersion(model *game.PlayerModel) *game.Client {
+	if model == nil {
+		return cli.Client
+	}
+	return cli.Client.AtVersion(model.Version)
+}
+
+func intArg(args []string, usage string) (int, error) {
+	if len(args) != 1 {
+		return 0, fmt.Errorf("usage: %s", usage)
+	}
+	value, err := strconv.Atoi(args[0])
+	if err != nil {
+		return 0, fmt.Errorf("usage: %s", usage)
+	}
+	return value, nil
+}
+
+// cardArg reads a card in short notation, or by its number in the list of legal cards
+func cardArg(args []string, model *game.PlayerModel) (*game.Card, error) {
+	if len(args) != 1 || model == nil {
+		return nil, fmt.Errorf("usage: play <card>")
+	}
+	if index, err := strconv.Atoi(args[0]); err == nil {
+		if index < 1 || index > len(model.LegalCards) {
+			return nil, fmt.Errorf("no card number %d to play", index)
+		}
+		return model.LegalCards[index-1], nil
+	}
+	return game.ParseCard(model.Deck(), args[0])
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"github.com/mattfenwick/upanddowntheriver/pkg/game"
+	"strings"
+	"text/tabwriter"
+)
+
+func cardString(deck game.Deck, card *game.Card) string {
+	if card == nil {
+		return ""
+	}
+	return game.FormatCardUnicode(deck, card)
+}
+
+func optionalInt(i *int) string {
+	if i == nil {
+		return ""
+	}
+	return fmt.Sprintf("%d", *i)
+}
+
+// renderModel draws everything a player can see as plain text
+func renderModel(pm *game.PlayerModel) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "==== version %d: %s ====\n", pm.Version, pm.State.JSONString())
+	fmt.Fprintf(&sb, "players: %s\n", strings.Join(pm.Game.Players, ", "))
+	fmt.Fprintf(&sb, "deck: %s, %d cards per player (max %d)\n", pm.Game.DeckType.JSONString(), pm.Game.CardsPerPlayer, pm.Game.MaxCardsPerPlayer)
+	if pm.Announcement != "" {
+		fmt.Fprintf(&sb, "announcement: %s\n", pm.Announcement)
+	}
+	if pm.Status == nil {
+		return sb.String()
+	}
+
+	status := pm.Status
+	deck := pm.Deck()
+	fmt.Fprintf(&sb, "trump: %s\n", status.TrumpSuit)
+	if status.CurrentHand != nil && status.CurrentHand.Suit != "" {
+		fmt.Fprintf(&sb, "suit: %s\n", status.CurrentHand.Suit)
+	}
+	fmt.Fprintf(&sb, "wagers so far: %d\n\n", status.WagerSum)
+
+	table := tabwriter.NewWriter(&sb, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(table, "\tplayer\twager\twon\tmood\tcurrent card\tprevious card")
+	for _, ps := range status.PlayerStatuses {
+		marker := ""
+		if ps.IsNextWagerer || ps.IsNextPlayer {
+			marker = "->"
+		}
+		name := ps.Player
+		if ps.IsMe {
+			name += " (me)"
+		}
+		fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", marker, name, optionalInt(ps.Wager), optionalInt(ps.HandsWon), ps.Mood.JSONString(), cardString(deck, ps.CurrentCard), cardString(deck, ps.PreviousCard))
+	}
+	table.Flush()
+
+	if len(status.Tricks) > 0 {
+		sb.WriteString("\ntricks:\n")
+		for i, trick := range status.Tricks {
+			plays := []string{}
+			for _, play := range trick.Cards {
+				plays = append(plays, fmt.Sprintf("%s: %s", play.Player, cardString(deck, play.Card)))
+			}
+			fmt.Fprintf(&sb, "  %d. %s -- won by %s\n", i+1, strings.Join(plays, ", "), trick.Winner)
+		}
+	}
+
+	cards := []string{}
+	for _, card := range pm.MyCards {
+		cards = append(cards, cardString(deck, card))
+	}
+	fmt.Fprintf(&sb, "\nmy hand: %s\n", strings.Join(cards, ", "))
+	return sb.String()
+}
+
+// renderPrompt tells the player what they can do next, if anything
+func renderPrompt(pm *game.PlayerModel) string {
+	switch pm.State {
+	case game.PlayerStateNotJoined:
+		return "join the game with: join <name>"
+	case game.PlayerStateWaitingForPlayers:
+		return "waiting for players -- start the round with: start"
+	case game.PlayerStateRoundFinished:
+		return "round over -- start the next one with: finish"
+	}
+	if len(pm.LegalWagers) > 0 {
+		wagers := []string{}
+		for _, wager := range pm.LegalWagers {
+			wagers = append(wagers, fmt.Sprintf("%d", wager))
+		}
+		return fmt.Sprintf("your turn to wager, one of %s: wager <hands>", strings.Join(wagers, ", "))
+	}
+	if len(pm.LegalCards) > 0 {
+		cards := []string{}
+		for i, card := range pm.LegalCards {
+			cards = append(cards, fmt.Sprintf("%d) %s", i+1, game.FormatCard(pm.Deck(), card)))
+		}
+		return fmt.Sprintf("your turn to play, one of %s: play <card or number>", strings.Join(cards, "  "))
+	}
+	return "waiting for other players"
+}
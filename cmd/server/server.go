@@ -1,10 +1,24 @@
 package main
 
 import (
+	"embed"
 	"github.com/mattfenwick/upanddowntheriver/pkg/game"
+	log "github.com/sirupsen/logrus"
+	"io/fs"
 	"os"
 )
 
+// uiFiles is the ui, built into the server so that it doesn't need a copy on disk
+//
+//go:embed ui
+var uiFiles embed.FS
+
 func main() {
-	game.Run(os.Args[1])
+	ui, err := fs.Sub(uiFiles, "ui")
+	if err != nil {
+		log.Fatalf("unable to find ui: %+v", err)
+	}
+	if err := game.Run(os.Args[1], ui); err != nil {
+		log.Fatalf("unable to continue: %+v", err)
+	}
 }
@@ -127,10 +127,27 @@ function Card(suit, number) {
 //     console.log("fired off GET to /model");
 // }
 
+// the version of the game we last saw, so that actions from a stale screen are rejected
+let lastSeenVersion = null;
+
 function postAction(payload, cont) {
+    if ( lastSeenVersion !== null && !('GetModel' in payload) ) {
+        payload['ExpectedVersion'] = lastSeenVersion;
+    }
     function f(data, status, _jqXHR) {
         console.log("post /action response -- status " + status);
-        cont(status === 'success', data);
+        if ( status === 'success' ) {
+            lastSeenVersion = data.Version;
+            cont(true, data);
+            return;
+        }
+        // on errors, data is the jqXHR
+        let current = conflictPlayerModel(data);
+        if ( current !== null ) {
+            console.log(`game has moved on to version ${current.Version}, action not applied`);
+            lastSeenVersion = current.Version;
+        }
+        cont(false, data);
     }
     $.post({
         'url': '/action',
@@ -143,6 +160,16 @@ function postAction(payload, cont) {
     console.log("fired off POST to /action");
 }
 
+// conflictPlayerModel is the up-to-date model sent back when an action is rejected for being
+// based on an old version of the game -- or null, for any other failure
+function conflictPlayerModel(jqXHR) {
+    let error = jqXHR.responseJSON;
+    if ( error && error.Code === 'VersionConflict' && error.Details.PlayerModel ) {
+        return error.Details.PlayerModel;
+    }
+    return null;
+}
+
 function getMyModel(me, cont) {
     postAction({'Me': me, 'GetModel': {}}, cont);
 }
@@ -179,6 +206,10 @@ function postFinishRound(me, cont) {
     postAction({'Me': me, 'FinishRound': {}}, cont);
 }
 
+function postFinishGame(me, cont) {
+    postAction({'Me': me, 'FinishGame': {}}, cont);
+}
+
 // util
 
 function equals(a, b) {
@@ -246,9 +277,46 @@ Me.prototype.update = function(name) {
     }
 };
 
+// Announcement
+
+function Announcement() {
+    this.message = "";
+    this.div = $("#announcement");
+    this.div.hide();
+}
+
+Announcement.prototype.update = function(message) {
+    if ( message === this.message ) { return; }
+    this.message = message;
+    this.div.empty();
+    if ( message === "" ) {
+        this.div.hide();
+    } else {
+        this.div.append(escapeHtml(message));
+        this.div.show();
+    }
+};
+
+// Notice
+
+// Notice briefly tells the player about something that happened to their own action
+function Notice() {
+    this.div = $("#notice");
+    this.div.hide();
+    this.timeout = null;
+}
+
+Notice.prototype.show = function(message) {
+    this.div.empty();
+    this.div.append(escapeHtml(message));
+    this.div.show();
+    clearTimeout(this.timeout);
+    this.timeout = setTimeout(() => this.div.hide(), 5000);
+};
+
 // Game
 
-function Game(didClickRemovePlayer, didChangeCardsPerPlayer, didChangeDeckType, didClickStartRound) {
+function Game(didClickRemovePlayer, didChangeCardsPerPlayer, didChangeDeckType, didClickStartRound, didClickFinishGame) {
     this.players = [];
     this.cardsPerPlayer = null;
     this.maxCardsPerPlayer = null;
@@ -257,6 +325,8 @@ function Game(didClickRemovePlayer, didChangeCardsPerPlayer, didChangeDeckType,
     this.div = $("#game");
     this.startButton = $("#round-start-button");
     this.startButton.click(didClickStartRound);
+    this.finishGameButton = $("#game-finish-button");
+    this.finishGameButton.click(didClickFinishGame);
 
     let self = this;
 
@@ -291,6 +361,7 @@ Game.prototype.setStateNotJoined = function(players) {
     this.div.show();
     $(".game-remove-player").hide();
     this.startButton.hide();
+    this.finishGameButton.hide();
     this.cardsPerPlayerContainer.hide();
     this.deckTypeContainer.hide();
 };
@@ -304,6 +375,7 @@ Game.prototype.setStateWaitingForPlayers = function(players, cardsPerPlayer, max
     $(".game-remove-player").show();
     this.startButton.prop('disabled', this.players.length < 2);
     this.startButton.show();
+    this.finishGameButton.show();
     this.cardsPerPlayerContainer.show();
     this.deckTypeContainer.show();
 };
@@ -381,11 +453,31 @@ MyCards.prototype.setRoundFinished = function() {
 
 MyCards.prototype.setWagerTurn = function(cards) {
     this.setCards(cards);
+    this.setLegalCards([]);
 };
 
-MyCards.prototype.setPlayCardTurn = function(cards, nextHandPlayer) {
-    this.setCards(cards, nextHandPlayer);
+MyCards.prototype.setPlayCardTurn = function(cards, nextHandPlayer, legalCards) {
+    this.setCards(cards);
     this.setNextHandPlayer(nextHandPlayer);
+    this.setLegalCards(legalCards || []);
+};
+
+// only legal cards can be clicked; the rest are greyed out
+MyCards.prototype.setLegalCards = function(legalCards) {
+    let legal = {};
+    legalCards.forEach(function(card) {
+        legal[`${card.Suit}-${card.Number}`] = true;
+    });
+    $('#my-cards .card').each(function() {
+        let key = `${$(this).attr('uadtr-card-suit')}-${$(this).attr('uadtr-card-number')}`;
+        if ( legal[key] ) {
+            $(this).removeClass("card-illegal");
+            $(this).css('pointer-events', 'auto');
+        } else {
+            $(this).addClass("card-illegal");
+            $(this).css('pointer-events', 'none');
+        }
+    });
 };
 
 MyCards.prototype.setNextHandPlayer = function(nextHandPlayer) {
@@ -493,8 +585,8 @@ Status.prototype.setOtherStates = function() {
     this.cont.hide();
 };
 
-Status.prototype.setWagerTurn = function(statuses, cardsPerPlayer) {
-    this.setPlayerStatuses(statuses, cardsPerPlayer);
+Status.prototype.setWagerTurn = function(statuses, legalWagers) {
+    this.setPlayerStatuses(statuses, legalWagers);
     this.cont.show();
 };
 
@@ -527,17 +619,20 @@ function moodToUnicode(mood) {
     throw new Error(`unrecognized mood ${mood}`);
 }
 
-function buildStatusTableModel(statuses, cardsPerPlayer) {
+function buildStatusTableModel(statuses, currentHand, legalWagers) {
+    let playOrder = {};
+    if ( currentHand ) {
+        currentHand.Plays.forEach(function(play, ix) {
+            playOrder[play.Player] = ix + 1;
+        });
+    }
     let rows = [];
     statuses.forEach(function(status) {
         let wager = {
             'count': status.Wager,
         };
-        if ( status.IsNextWagerer && status.IsMe ) {
-            wager.options = [];
-            for ( let i = 0; i <= cardsPerPlayer; i++ ) {
-                wager.options.push(i);
-            }
+        if ( status.IsNextWagerer && status.IsMe && legalWagers ) {
+            wager.options = legalWagers;
         }
         let cc = status.CurrentCard;
         let pc = status.PreviousCard;
@@ -555,6 +650,7 @@ function buildStatusTableModel(statuses, cardsPerPlayer) {
             'handsWon': status.HandsWon,
             'prevCard': pc ? [pc.Suit, pc.Number] : null,
             'currCard': cc ? [cc.Suit, cc.Number] : null,
+            'currPlayOrder': (status.Player in playOrder) ? playOrder[status.Player] : null,
         };
         rows.push(row);
     });
@@ -575,14 +671,14 @@ function statusSuit(suit) {
     return elems.join("\n");
 }
 
-Status.prototype.setPlayerStatuses = function(status, cardsPerPlayer) {
-    let next = [status, cardsPerPlayer];
+Status.prototype.setPlayerStatuses = function(status, legalWagers) {
+    let next = [status, legalWagers];
     if ( equals(this.current, next) ) {
         return;
     }
     this.current = next;
 
-    let model = buildStatusTableModel(status.PlayerStatuses, cardsPerPlayer);
+    let model = buildStatusTableModel(status.PlayerStatuses, status.CurrentHand, legalWagers);
     this.statusTableBody.empty();
     let ph = status.PreviousHand;
     let ch = status.CurrentHand;
@@ -617,7 +713,7 @@ Status.prototype.setPlayerStatuses = function(status, cardsPerPlayer) {
             <td class="status-wager">${wager}</td>
             <td class="status-hands-won">${(status.handsWon !== null) ? status.handsWon : ""}</td>
             <td class="status-previous-card">${(status.prevCard !== null) ? Card(status.prevCard[0], status.prevCard[1]) : ""}</td>
-            <td class="status-current-card">${(status.currCard !== null) ? Card(status.currCard[0], status.currCard[1]) : ""}</td>
+            <td class="status-current-card">${(status.currCard !== null) ? `<div class="status-play-order">#${status.currPlayOrder}</div>` + Card(status.currCard[0], status.currCard[1]) : ""}</td>
         `;
         let klazzes = [];
         for ( let klazz in status.classes ) {
@@ -630,6 +726,43 @@ Status.prototype.setPlayerStatuses = function(status, cardsPerPlayer) {
     });
 };
 
+// tricks
+
+function Tricks() {
+    this.cont = $("#tricks");
+    this.tableBody = $("#tricks-table tbody");
+    this.tricks = [];
+
+    this.setOtherStates();
+}
+
+Tricks.prototype.setOtherStates = function() {
+    this.cont.hide();
+};
+
+Tricks.prototype.setTricks = function(tricks) {
+    this.cont.show();
+    if ( equals(tricks, this.tricks) ) {
+        return;
+    }
+    this.tricks = tricks;
+    this.tableBody.empty();
+    let self = this;
+    tricks.forEach(function(trick, ix) {
+        let cards = trick.Cards.map(function(tc) {
+            let klazz = (tc.Player === trick.Winner) ? "tricks-winner" : "";
+            return `<td class="${klazz}">${escapeHtml(tc.Player)}${Card(tc.Card.Suit, tc.Card.Number)}</td>`;
+        });
+        self.tableBody.append(`
+            <tr>
+                <td>${ix + 1}</td>
+                <td>${statusSuit(trick.Suit)}</td>
+                ${cards.join("\n")}
+                <td>won by ${escapeHtml(trick.Winner)}</td>
+            </tr>`);
+    });
+};
+
 // model
 
 function Model() {
@@ -640,6 +773,9 @@ function Model() {
     }
     this.me = new Me(didClickJoin);
 
+    this.announcement = new Announcement();
+    this.notice = new Notice();
+
     function didClickRemovePlayer(player) {
         self.removePlayer(player);
     }
@@ -652,7 +788,10 @@ function Model() {
     function didClickStartRound() {
         self.startRound();
     }
-    this.game = new Game(didClickRemovePlayer, didChangeCardsPerPlayer, didChangeDeckType, didClickStartRound);
+    function didClickFinishGame() {
+        self.finishGame();
+    }
+    this.game = new Game(didClickRemovePlayer, didChangeCardsPerPlayer, didChangeDeckType, didClickStartRound, didClickFinishGame);
 
     function didClickFinishRound() {
         self.finishRound();
@@ -669,6 +808,8 @@ function Model() {
     }
     this.myCards = new MyCards(didClickPlayCard);
 
+    this.tricks = new Tricks();
+
     this.pollServer();
 }
 
@@ -678,43 +819,55 @@ Model.prototype.pollServer = function() {
 };
 
 Model.prototype.updateFromServer = function(ok, data) {
-    if ( !ok ) { return; }
+    if ( !ok ) {
+        // a rejected action still brings the latest game with it, so catch up
+        let current = conflictPlayerModel(data);
+        if ( current === null ) { return; }
+        this.notice.show("The game changed before that got there, so it wasn't applied -- take another look.");
+        data = current;
+    }
 
     let me = data.Me;
     let game = data.Game;
     this.me.update(me);
     this.myCards.me = me;
+    this.announcement.update(data.Announcement || "");
     switch (data.State) {
         case "NotJoined":
             this.game.setStateNotJoined(game.Players);
             this.myCards.setOtherStates();
             this.round.setOtherStates();
             this.status.setOtherStates();
+            this.tricks.setOtherStates();
             break;
         case "WaitingForPlayers":
             this.game.setStateWaitingForPlayers(game.Players, game.CardsPerPlayer, game.MaxCardsPerPlayer, game.DeckType);
             this.myCards.setOtherStates();
             this.round.setOtherStates();
             this.status.setOtherStates();
+            this.tricks.setOtherStates();
             break;
         case "WagerTurn":
             this.game.setOtherStates();
             this.myCards.setWagerTurn(data.MyCards);
             this.round.setWagerTurn(data.Status.TrumpSuit);
-            this.status.setWagerTurn(data.Status, game.CardsPerPlayer);
+            this.status.setWagerTurn(data.Status, data.LegalWagers);
+            this.tricks.setTricks(data.Status.Tricks);
             break;
         case "PlayCardTurn":
             let nextPlayer = data.Status.CurrentHand.NextPlayer;
             this.game.setOtherStates();
-            this.myCards.setPlayCardTurn(data.MyCards, nextPlayer);
+            this.myCards.setPlayCardTurn(data.MyCards, nextPlayer, data.LegalCards);
             this.round.setPlayCardTurn(data.Status.TrumpSuit);
             this.status.setPlayCardTurn(data.Status);
+            this.tricks.setTricks(data.Status.Tricks);
             break;
         case "RoundFinished":
             this.game.setOtherStates();
             this.myCards.setRoundFinished();
             this.round.setRoundFinished(data.Status.TrumpSuit);
             this.status.setRoundFinished(data.Status);
+            this.tricks.setTricks(data.Status.Tricks);
             break;
         default:
             throw new Error(`unrecognized state ${data.State}`);
@@ -763,6 +916,11 @@ Model.prototype.finishRound = function() {
     postFinishRound(this.me.name, this.updateFromServer.bind(this));
 };
 
+Model.prototype.finishGame = function() {
+    console.log("finishing game");
+    postFinishGame(this.me.name, this.updateFromServer.bind(this));
+};
+
 
 //
 
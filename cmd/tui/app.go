@@ -0,0 +1,226 @@
+package main
+
+import (
+	"context"
+	"github.com/gdamore/tcell"
+	"github.com/mattfenwick/upanddowntheriver/pkg/game"
+	log "github.com/sirupsen/logrus"
+	"time"
+)
+
+const (
+	pollInterval   = time.Second
+	requestTimeout = 15 * time.Second
+)
+
+// modelUpdate is posted to the event loop whenever a request finishes
+type modelUpdate struct {
+	Model *game.PlayerModel
+	Err   error
+}
+
+// App is a full-screen client.  All of its state is owned by the event loop in Run: polling
+// and actions happen on other goroutines, and post their results back as events.
+type App struct {
+	Client *game.Client
+	Screen tcell.Screen
+	Me     string
+	model  *game.PlayerModel
+	// selected indexes into the model's legal wagers or legal cards, whichever it has
+	selected int
+	message  string
+}
+
+func NewApp(client *game.Client, screen tcell.Screen, me string) *App {
+	return &App{Client: client, Screen: screen, Me: me}
+}
+
+// Run handles events until the player quits
+func (app *App) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go app.poll(ctx)
+
+	app.draw()
+	for {
+		switch ev := app.Screen.PollEvent().(type) {
+		case *tcell.EventResize:
+			app.Screen.Sync()
+		case *tcell.EventInterrupt:
+			app.update(ev.Data().(*modelUpdate))
+		case *tcell.EventKey:
+			if app.handleKey(ctx, ev) {
+				return nil
+			}
+		case nil:
+			// the screen has been finalized
+			return nil
+		}
+		app.draw()
+	}
+}
+
+func (app *App) poll(ctx context.Context) {
+	for {
+		requestCtx, cancel := context.WithTimeout(ctx, requestTimeout)
+		pm, err := app.Client.GetMyModel(requestCtx, app.Me)
+		cancel()
+		if err != nil {
+			log.Errorf("unable to get model: %+v", err)
+		} else {
+			app.Screen.PostEvent(tcell.NewEventInterrupt(&modelUpdate{Model: pm}))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// act runs a request off the event loop, so that the screen stays responsive
+func (app *App) act(ctx context.Context, request func(ctx context.Context) (*game.PlayerModel, error)) {
+	go func() {
+		requestCtx, cancel := context.WithTimeout(ctx, requestTimeout)
+		defer cancel()
+		pm, err := request(requestCtx)
+		app.Screen.PostEvent(tcell.NewEventInterrupt(&modelUpdate{Model: pm, Err: err}))
+	}()
+}
+
+// atVersion makes an action conditional on the game being just as it's shown on screen
+func (app *App) atVersion() *game.Client {
+	return app.Client.AtVersion(app.model.Version)
+}
+
+func (app *App) update(mu *modelUpdate) {
+	pm := mu.Model
+	if current, ok := game.ConflictPlayerModel(mu.Err); ok {
+		app.message = "the game changed before that got there, so it wasn't applied"
+		pm = current
+	} else if mu.Err != nil {
+		app.message = mu.Err.Error()
+		return
+	}
+	if app.model != nil && pm.Game.Guid == app.model.Game.Guid && pm.Version <= app.model.Version {
+		// nothing new -- or an out of order response, which shouldn't take us back in time.  A
+		// different game -- say, after a server restart -- starts its versions over, so always
+		// take its model.
+		return
+	}
+	if mu.Err == nil {
+		app.message = ""
+	}
+
+	// keep the same card selected, if it's still playable
+	selectedCard := app.selectedCard()
+	app.model = pm
+	app.selected = 0
+	if selectedCard != nil {
+		for i, card := range pm.LegalCards {
+			if card.Key() == selectedCard.Key() {
+				app.selected = i
+			}
+		}
+	}
+}
+
+func (app *App) selectedCard() *game.Card {
+	if app.model == nil || app.selected >= len(app.model.LegalCards) {
+		return nil
+	}
+	return app.model.LegalCards[app.selected]
+}
+
+func (app *App) choices() int {
+	if app.model == nil {
+		return 0
+	}
+	if len(app.model.LegalWagers) > 0 {
+		return len(app.model.LegalWagers)
+	}
+	return len(app.model.LegalCards)
+}
+
+// handleKey responds to a key press, returning whether it's time to quit
+func (app *App) handleKey(ctx context.Context, ev *tcell.EventKey) bool {
+	switch ev.Key() {
+	case tcell.KeyEscape, tcell.KeyCtrlC:
+		return true
+	case tcell.KeyLeft:
+		if choices := app.choices(); choices > 0 {
+			app.selected = (app.selected + choices - 1) % choices
+		}
+		return false
+	case tcell.KeyRight:
+		if choices := app.choices(); choices > 0 {
+			app.selected = (app.selected + 1) % choices
+		}
+		return false
+	case tcell.KeyEnter:
+		app.choose(ctx)
+		return false
+	case tcell.KeyRune:
+	default:
+		return false
+	}
+
+	pm := app.model
+	if ev.Rune() == 'q' {
+		return true
+	}
+	if pm == nil {
+		return false
+	}
+	switch ev.Rune() {
+	case 's':
+		app.act(ctx, func(ctx context.Context) (*game.PlayerModel, error) {
+			return app.Client.StartRound(ctx, app.Me)
+		})
+	case 'f':
+		app.act(ctx, func(ctx context.Context) (*game.PlayerModel, error) {
+			return app.Client.FinishRound(ctx, app.Me)
+		})
+	case 'e':
+		app.act(ctx, func(ctx context.Context) (*game.PlayerModel, error) {
+			return app.Client.FinishGame(ctx, app.Me)
+		})
+	case '+', '-':
+		count := pm.Game.CardsPerPlayer + 1
+		if ev.Rune() == '-' {
+			count = pm.Game.CardsPerPlayer - 1
+		}
+		app.act(ctx, func(ctx context.Context) (*game.PlayerModel, error) {
+			return app.Client.SetCardsPerPlayer(ctx, app.Me, count)
+		})
+	case 'd':
+		deckType := game.DeckTypeDoubleStandard
+		if pm.Game.DeckType == game.DeckTypeDoubleStandard {
+			deckType = game.DeckTypeStandard
+		}
+		app.act(ctx, func(ctx context.Context) (*game.PlayerModel, error) {
+			return app.Client.SetDeckType(ctx, app.Me, deckType)
+		})
+	}
+	return false
+}
+
+// choose makes the selected wager, or plays the selected card
+func (app *App) choose(ctx context.Context) {
+	pm := app.model
+	if pm == nil {
+		return
+	}
+	if len(pm.LegalWagers) > 0 {
+		hands := pm.LegalWagers[app.selected]
+		client := app.atVersion()
+		app.act(ctx, func(ctx context.Context) (*game.PlayerModel, error) {
+			return client.MakeWager(ctx, app.Me, hands)
+		})
+	} else if card := app.selectedCard(); card != nil {
+		client := app.atVersion()
+		app.act(ctx, func(ctx context.Context) (*game.PlayerModel, error) {
+			return client.PlayCard(ctx, app.Me, card)
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"github.com/mattfenwick/upanddowntheriver/pkg/game"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func playerModelAt(guid string, version int) *game.PlayerModel {
+	return &game.PlayerModel{Version: version, Me: "abc", Game: &game.PlayerGame{Guid: guid, Players: []string{"abc"}}}
+}
+
+func RunAppTests() {
+	Describe("App", func() {
+		It("should ignore out of order models from the same game", func() {
+			app := NewApp(nil, nil, "abc")
+			app.update(&modelUpdate{Model: playerModelAt("game-1", 5)})
+			app.update(&modelUpdate{Model: playerModelAt("game-1", 4)})
+			Expect(app.model.Version).To(Equal(5))
+			app.update(&modelUpdate{Model: playerModelAt("game-1", 6)})
+			Expect(app.model.Version).To(Equal(6))
+		})
+
+		It("should take a model from a fresh game, even at a lower version", func() {
+			app := NewApp(nil, nil, "abc")
+			app.update(&modelUpdate{Model: playerModelAt("game-1", 5)})
+			// the server restarted without a state file
+			app.update(&modelUpdate{Model: playerModelAt("game-2", 0)})
+			Expect(app.model.Game.Guid).To(Equal("game-2"))
+			Expect(app.model.Version).To(Equal(0))
+			app.update(&modelUpdate{Model: playerModelAt("game-2", 1)})
+			Expect(app.model.Version).To(Equal(1))
+		})
+	})
+}
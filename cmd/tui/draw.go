@@ -0,0 +1,266 @@
+package main
+
+import (
+	"fmt"
+	"github.com/gdamore/tcell"
+	"github.com/mattfenwick/upanddowntheriver/pkg/game"
+	"github.com/mattn/go-runewidth"
+	"strings"
+)
+
+var (
+	styleDefault  = tcell.StyleDefault
+	styleTitle    = tcell.StyleDefault.Bold(true)
+	styleRed      = tcell.StyleDefault.Foreground(tcell.ColorRed)
+	styleDim      = tcell.StyleDefault.Dim(true)
+	styleSelected = tcell.StyleDefault.Reverse(true)
+	styleError    = tcell.StyleDefault.Foreground(tcell.ColorRed).Bold(true)
+	styleNext     = tcell.StyleDefault.Foreground(tcell.ColorYellow).Bold(true)
+)
+
+func suitStyle(suit string, style tcell.Style) tcell.Style {
+	if suit == "Hearts" || suit == "Diamonds" {
+		fg, _, _ := styleRed.Decompose()
+		return style.Foreground(fg)
+	}
+	return style
+}
+
+// moodText is a short, readable version of each mood, to fit in the table
+var moodText = map[game.PlayerMood]string{
+	game.PlayerMoodNone:            "",
+	game.PlayerMoodLost:            "lost",
+	game.PlayerMoodLostBadly:       "lost badly",
+	game.PlayerMoodLostReallyBadly: "lost really badly",
+	game.PlayerMoodScared:          "scared",
+	game.PlayerMoodWinnable:        "winnable",
+	game.PlayerMoodBarelyWinnable:  "barely winnable",
+	game.PlayerMoodPotato:          "potato",
+	game.PlayerMoodWon:             "won",
+}
+
+// drawText writes text starting at x, y, cutting it off at maxWidth cells; it returns the
+// x just past the end of what was drawn
+func drawText(screen tcell.Screen, x int, y int, maxWidth int, style tcell.Style, text string) int {
+	end := x + maxWidth
+	for _, r := range text {
+		width := runewidth.RuneWidth(r)
+		if x+width > end {
+			break
+		}
+		screen.SetContent(x, y, r, nil, style)
+		x += width
+	}
+	return x
+}
+
+// drawBox outlines a panel, with its title in the top border
+func drawBox(screen tcell.Screen, x int, y int, width int, height int, title string) {
+	if width < 2 || height < 2 {
+		return
+	}
+	for i := x + 1; i < x+width-1; i++ {
+		screen.SetContent(i, y, tcell.RuneHLine, nil, styleDefault)
+		screen.SetContent(i, y+height-1, tcell.RuneHLine, nil, styleDefault)
+	}
+	for j := y + 1; j < y+height-1; j++ {
+		screen.SetContent(x, j, tcell.RuneVLine, nil, styleDefault)
+		screen.SetContent(x+width-1, j, tcell.RuneVLine, nil, styleDefault)
+	}
+	screen.SetContent(x, y, tcell.RuneULCorner, nil, styleDefault)
+	screen.SetContent(x+width-1, y, tcell.RuneURCorner, nil, styleDefault)
+	screen.SetContent(x, y+height-1, tcell.RuneLLCorner, nil, styleDefault)
+	screen.SetContent(x+width-1, y+height-1, tcell.RuneLRCorner, nil, styleDefault)
+	drawText(screen, x+2, y, width-4, styleTitle, fmt.Sprintf(" %s ", title))
+}
+
+// drawCard draws a card in its suit's color, returning the x just past it
+func drawCard(screen tcell.Screen, deck game.Deck, x int, y int, maxWidth int, style tcell.Style, card *game.Card) int {
+	if card == nil {
+		return x
+	}
+	return drawText(screen, x, y, maxWidth, suitStyle(card.Suit, style), game.FormatCardUnicode(deck, card))
+}
+
+func (app *App) draw() {
+	screen := app.Screen
+	screen.Clear()
+	width, height := screen.Size()
+	pm := app.model
+
+	header := fmt.Sprintf("up and down the river -- %s", app.Me)
+	if pm != nil {
+		header = fmt.Sprintf("%s -- %s -- version %d", header, pm.State.JSONString(), pm.Version)
+	}
+	drawText(screen, 0, 0, width, styleTitle, header)
+
+	leftWidth := width * 3 / 5
+	rightWidth := width - leftWidth
+	handHeight := 5
+	tableHeight := height - 2 - handHeight - 1
+	trumpHeight := 4
+
+	app.drawTable(0, 1, leftWidth, tableHeight)
+	app.drawHand(0, 1+tableHeight, leftWidth, handHeight)
+	app.drawTrump(leftWidth, 1, rightWidth, trumpHeight)
+	app.drawTricks(leftWidth, 1+trumpHeight, rightWidth, tableHeight+handHeight-trumpHeight)
+
+	if app.message != "" {
+		drawText(screen, 0, height-2, width, styleError, app.message)
+	} else if pm != nil && pm.Announcement != "" {
+		drawText(screen, 0, height-2, width, styleNext, fmt.Sprintf("announcement: %s", pm.Announcement))
+	}
+	drawText(screen, 0, height-1, width, styleDim, app.keyHelp())
+	screen.Show()
+}
+
+func (app *App) drawTable(x int, y int, width int, height int) {
+	screen := app.Screen
+	drawBox(screen, x, y, width, height, "table")
+	pm := app.model
+	if pm == nil {
+		return
+	}
+	inner := width - 4
+	row := y + 1
+	if pm.Status == nil {
+		drawText(screen, x+2, row, inner, styleDefault, fmt.Sprintf("deck: %s, %d cards per player (max %d)", pm.Game.DeckType.JSONString(), pm.Game.CardsPerPlayer, pm.Game.MaxCardsPerPlayer))
+		row += 2
+		for _, player := range pm.Game.Players {
+			if row >= y+height-1 {
+				break
+			}
+			drawText(screen, x+2, row, inner, styleDefault, player)
+			row++
+		}
+		return
+	}
+
+	columns := []int{0, 16, 23, 28, 46}
+	for i, title := range []string{"player", "wager", "won", "mood", "card"} {
+		drawText(screen, x+2+columns[i], row, inner-columns[i], styleTitle, title)
+	}
+	row++
+	for _, ps := range pm.Status.PlayerStatuses {
+		if row >= y+height-1 {
+			break
+		}
+		style := styleDefault
+		if ps.IsNextWagerer || ps.IsNextPlayer {
+			style = styleNext
+		}
+		name := ps.Player
+		if ps.IsMe {
+			name = "*" + name
+		}
+		cells := []string{name, optionalInt(ps.Wager), optionalInt(ps.HandsWon), moodText[ps.Mood]}
+		for i, cell := range cells {
+			drawText(screen, x+2+columns[i], row, columns[i+1]-columns[i]-1, style, cell)
+		}
+		drawCard(screen, pm.Deck(), x+2+columns[4], row, inner-columns[4], styleDefault, ps.CurrentCard)
+		row++
+	}
+	row++
+	if row < y+height-1 {
+		drawText(screen, x+2, row, inner, styleDefault, fmt.Sprintf("wagers: %d of %d", pm.Status.WagerSum, pm.Game.CardsPerPlayer))
+	}
+}
+
+func (app *App) drawHand(x int, y int, width int, height int) {
+	screen := app.Screen
+	drawBox(screen, x, y, width, height, "my hand")
+	pm := app.model
+	if pm == nil {
+		return
+	}
+	legal := map[string]bool{}
+	for _, card := range pm.LegalCards {
+		legal[card.Key()] = true
+	}
+	selected := app.selectedCard()
+	cx, end := x+2, x+width-2
+	for _, card := range pm.MyCards {
+		style := styleDefault
+		if len(pm.LegalCards) > 0 && !legal[card.Key()] {
+			style = styleDim
+		}
+		if selected != nil && card.Key() == selected.Key() {
+			style = styleSelected
+			// only highlight one copy of a duplicated card
+			selected = nil
+		}
+		cx = drawCard(screen, pm.Deck(), cx, y+2, end-cx, style, card) + 1
+	}
+
+	if len(pm.LegalWagers) > 0 {
+		prompt := fmt.Sprintf("wager: %d", pm.LegalWagers[app.selected])
+		drawText(screen, x+2, y+3, width-4, styleNext, prompt)
+	}
+}
+
+func (app *App) drawTrump(x int, y int, width int, height int) {
+	screen := app.Screen
+	drawBox(screen, x, y, width, height, "trump")
+	pm := app.model
+	if pm == nil || pm.Status == nil {
+		return
+	}
+	trump := pm.Status.TrumpSuit
+	drawText(screen, x+2, y+1, width-4, suitStyle(trump, styleTitle), fmt.Sprintf("%s %s", game.SuitSymbol(trump), trump))
+	if hand := pm.Status.CurrentHand; hand != nil && hand.Suit != "" {
+		drawText(screen, x+2, y+2, width-4, suitStyle(hand.Suit, styleDefault), fmt.Sprintf("led: %s %s", game.SuitSymbol(hand.Suit), hand.Suit))
+	}
+}
+
+func (app *App) drawTricks(x int, y int, width int, height int) {
+	screen := app.Screen
+	drawBox(screen, x, y, width, height, "tricks")
+	pm := app.model
+	if pm == nil || pm.Status == nil {
+		return
+	}
+	tricks := pm.Status.Tricks
+	rows := height - 2
+	// most recent at the bottom, dropping the oldest if they don't fit
+	if len(tricks) > rows {
+		tricks = tricks[len(tricks)-rows:]
+	}
+	for i, trick := range tricks {
+		row := y + 1 + i
+		cx, end := x+2, x+width-2
+		for _, play := range trick.Cards {
+			style := styleDefault
+			if play.Player == trick.Winner {
+				style = styleTitle
+			}
+			cx = drawCard(screen, pm.Deck(), cx, row, end-cx, style, play.Card) + 1
+		}
+		drawText(screen, cx, row, end-cx, styleDim, fmt.Sprintf("-> %s", trick.Winner))
+	}
+}
+
+func (app *App) keyHelp() string {
+	keys := []string{"q: quit"}
+	pm := app.model
+	if pm == nil {
+		return strings.Join(keys, "  ")
+	}
+	switch {
+	case pm.State == game.PlayerStateWaitingForPlayers:
+		keys = append(keys, "s: start round", "+/-: cards per player", "d: deck type", "e: end game")
+	case pm.State == game.PlayerStateRoundFinished:
+		keys = append(keys, "f: finish round")
+	case len(pm.LegalWagers) > 0:
+		keys = append(keys, "left/right: choose wager", "enter: wager")
+	case len(pm.LegalCards) > 0:
+		keys = append(keys, "left/right: choose card", "enter: play")
+	}
+	return strings.Join(keys, "  ")
+}
+
+func optionalInt(i *int) string {
+	if i == nil {
+		return ""
+	}
+	return fmt.Sprintf("%d", *i)
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"github.com/gdamore/tcell"
+	"github.com/mattfenwick/upanddowntheriver/pkg/game"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"io/ioutil"
+	"os"
+)
+
+type Config struct {
+	LogLevel string
+	// LogFile is where logs go, since they'd otherwise draw over the screen; if it's empty, logs are dropped
+	LogFile string
+	Host    string
+	Port    int
+	Name    string
+}
+
+// GetLogLevel ...
+func (config *Config) GetLogLevel() (log.Level, error) {
+	return log.ParseLevel(config.LogLevel)
+}
+
+// GetConfig ...
+func GetConfig(configPath string) (*Config, error) {
+	var config *Config
+
+	viper.SetConfigFile(configPath)
+	err := viper.ReadInConfig()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to ReadInConfig at %s", configPath)
+	}
+
+	err = viper.Unmarshal(&config)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal config at %s", configPath)
+	}
+
+	return config, nil
+}
+
+func doOrDie(err error) {
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+}
+
+func setupLogging(config *Config) {
+	logLevel, err := config.GetLogLevel()
+	doOrDie(err)
+	log.SetLevel(logLevel)
+
+	if config.LogFile == "" {
+		log.SetOutput(ioutil.Discard)
+		return
+	}
+	logFile, err := os.OpenFile(config.LogFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	doOrDie(errors.Wrapf(err, "unable to open log file %s", config.LogFile))
+	log.SetOutput(logFile)
+}
+
+func main() {
+	configPath := os.Args[1]
+	config, err := GetConfig(configPath)
+	doOrDie(err)
+	if config.Name == "" {
+		log.Fatalf("config %s must set Name", configPath)
+	}
+
+	client := game.NewClient(config.Host, config.Port)
+	client.Resty.SetLogger(log.StandardLogger())
+
+	// join before taking over the terminal, so that problems are easy to read
+	me, err := join(context.Background(), client, config.Name)
+	doOrDie(err)
+
+	setupLogging(config)
+
+	screen, err := tcell.NewScreen()
+	doOrDie(errors.Wrapf(err, "unable to create screen"))
+	doOrDie(errors.Wrapf(screen.Init(), "unable to initialize screen"))
+
+	app := NewApp(client, screen, me)
+	err = app.Run(context.Background())
+	screen.Fini()
+	doOrDie(err)
+}
+
+// join adds name to the game; if it's already there, this just picks up where we left off
+func join(ctx context.Context, client *game.Client, name string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+	pm, err := client.Join(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	return pm.Me, nil
+}
@@ -0,0 +1,14 @@
+package main
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestTui(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunAppTests()
+	RunSpecs(t, "tui suite")
+}
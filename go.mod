@@ -1,14 +1,18 @@
 module github.com/mattfenwick/upanddowntheriver
 
-go 1.13
+go 1.16
 
 require (
+	github.com/gdamore/tcell v1.4.0
 	github.com/go-resty/resty/v2 v2.2.0
+	github.com/golang/protobuf v1.3.2
 	github.com/google/uuid v1.1.1
+	github.com/mattn/go-runewidth v0.0.7
 	github.com/onsi/ginkgo v1.12.0
 	github.com/onsi/gomega v1.9.0
 	github.com/pkg/errors v0.9.1
 	github.com/prometheus/client_golang v1.5.1
 	github.com/sirupsen/logrus v1.5.0
 	github.com/spf13/viper v1.6.3
+	google.golang.org/grpc v1.27.1
 )
@@ -0,0 +1,180 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"net/http"
+)
+
+// AdminGame is how the admin api shows a game
+type AdminGame struct {
+	ID           string
+	Version      int
+	State        PlayerState
+	Players      []string
+	Announcement string
+}
+
+type AdminGameList struct {
+	Games []*AdminGame
+}
+
+// AdminBroadcastRequest sets the announcement shown at every table; an empty message clears it
+type AdminBroadcastRequest struct {
+	Message string
+}
+
+var adminRoutes = []*gameRoute{
+	{"GET", "{id}", adminDumpGame},
+	{"POST", "{id}/rounds/current/finish", adminFinishRound},
+	{"DELETE", "{id}/players/{player}", adminRemovePlayer},
+	{"POST", "{id}/reset", adminResetGame},
+}
+
+// setupAdminRoutes serves the admin api, for getting wedged games moving again.  Every
+// request needs the admin token, and every request -- allowed or not -- is audit-logged.
+func setupAdminRoutes(mux *http.ServeMux, registry *GameRegistry, adminToken string) {
+	mux.HandleFunc("/admin/games", requireAdmin(adminToken, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			methodNotAllowed(w, r, []string{"GET"})
+			return
+		}
+		list := &AdminGameList{Games: []*AdminGame{}}
+		for _, game := range registry.all() {
+			adminGame, err := newAdminGame(game)
+			if err != nil {
+				auditLog(r, "listGames", log.Fields{}, err)
+				writeError(w, err)
+				return
+			}
+			list.Games = append(list.Games, adminGame)
+		}
+		auditLog(r, "listGames", log.Fields{}, nil)
+		writeJson(w, list)
+	}))
+
+	mux.HandleFunc("/admin/games/", requireAdmin(adminToken, func(w http.ResponseWriter, r *http.Request) {
+		serveGameRoutes(w, r, "/admin/games/", adminRoutes, registry)
+	}))
+
+	mux.HandleFunc("/admin/broadcast", requireAdmin(adminToken, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			methodNotAllowed(w, r, []string{"POST"})
+			return
+		}
+		request := &AdminBroadcastRequest{}
+		if err := readBody(r, request); err != nil {
+			auditLog(r, "broadcast", log.Fields{}, err)
+			writeError(w, err)
+			return
+		}
+		ctx, cancel := context.WithTimeout(r.Context(), actionTimeout)
+		defer cancel()
+		list := &AdminGameList{Games: []*AdminGame{}}
+		for _, game := range registry.all() {
+			err := game.Responder.Announce(ctx, request.Message)
+			auditLog(r, "broadcast", log.Fields{"game": game.ID, "message": request.Message}, err)
+			if err != nil {
+				writeError(w, err)
+				return
+			}
+			adminGame, err := newAdminGame(game)
+			if err != nil {
+				writeError(w, err)
+				return
+			}
+			list.Games = append(list.Games, adminGame)
+		}
+		writeJson(w, list)
+	}))
+}
+
+// requireAdmin turns away requests without the admin token
+func requireAdmin(adminToken string, handle http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Debugf("receiving %s request to %s", r.Method, r.URL.String())
+		if err := checkAdmin(r, adminToken); err != nil {
+			auditLog(r, "authenticate", log.Fields{}, err)
+			writeError(w, err)
+			return
+		}
+		handle(w, r)
+	}
+}
+
+// auditLog records an admin request, and how it turned out
+func auditLog(r *http.Request, action string, fields log.Fields, err error) {
+	entry := log.WithFields(fields).WithFields(log.Fields{
+		"audit":  true,
+		"action": action,
+		"method": r.Method,
+		"path":   r.URL.Path,
+		"remote": r.RemoteAddr,
+	})
+	if err != nil {
+		entry.WithField("error", err.Error()).Warnf("admin action %s failed", action)
+		return
+	}
+	entry.Infof("admin action %s", action)
+}
+
+func newAdminGame(game *registeredGame) (*AdminGame, error) {
+	public, err := game.Responder.GetPublicModel()
+	if err != nil {
+		return nil, err
+	}
+	return &AdminGame{
+		ID:           game.ID,
+		Version:      public.Version,
+		State:        public.State,
+		Players:      public.Game.Players,
+		Announcement: public.Announcement,
+	}, nil
+}
+
+// adminAction applies an admin action with the usual timeout, audit-logs it, and responds
+// with the game as it is afterwards
+func adminAction(w http.ResponseWriter, r *http.Request, game *registeredGame, action string, fields log.Fields, apply func(ctx context.Context) error) {
+	ctx, cancel := context.WithTimeout(r.Context(), actionTimeout)
+	defer cancel()
+	fields["game"] = game.ID
+	err := apply(ctx)
+	auditLog(r, action, fields, err)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	adminGame, err := newAdminGame(game)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJson(w, adminGame)
+}
+
+func adminDumpGame(w http.ResponseWriter, r *http.Request, game *registeredGame, params map[string]string) {
+	model, err := game.Responder.GetModel()
+	auditLog(r, "dumpGame", log.Fields{"game": game.ID}, err)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set(http.CanonicalHeaderKey("content-type"), "application/json")
+	fmt.Fprint(w, model)
+}
+
+func adminFinishRound(w http.ResponseWriter, r *http.Request, game *registeredGame, params map[string]string) {
+	adminAction(w, r, game, "forceFinishRound", log.Fields{}, game.Responder.ForceFinishRound)
+}
+
+func adminRemovePlayer(w http.ResponseWriter, r *http.Request, game *registeredGame, params map[string]string) {
+	player := params["player"]
+	adminAction(w, r, game, "forceRemovePlayer", log.Fields{"player": player}, func(ctx context.Context) error {
+		return game.Responder.ForceRemovePlayer(ctx, player)
+	})
+}
+
+func adminResetGame(w http.ResponseWriter, r *http.Request, game *registeredGame, params map[string]string) {
+	adminAction(w, r, game, "resetGame", log.Fields{}, game.Responder.Reset)
+}
@@ -0,0 +1,85 @@
+package game
+
+import (
+	"context"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func RunAdminApiTests() {
+	Describe("Admin API", func() {
+		ctx := context.Background()
+
+		It("should turn away requests without the admin token", func() {
+			_, client, stop := newTestServer()
+			defer stop()
+			_, err := client.ListGames(ctx)
+			Expect(AsGameError(err).Code).To(Equal(ErrorCodeUnauthorized))
+
+			client.AdminToken = "wrong"
+			_, err = client.ResetGame(ctx, DefaultGameID)
+			Expect(AsGameError(err).Code).To(Equal(ErrorCodeUnauthorized))
+		})
+
+		It("should get a stuck game moving again", func() {
+			_, client, stop := newTestServer()
+			defer stop()
+			client.AdminToken = testAdminToken
+			for _, player := range []string{"abc", "def", "ghi"} {
+				_, err := client.Join(ctx, player)
+				Expect(err).Should(Succeed())
+			}
+			_, err := client.StartRound(ctx, "abc")
+			Expect(err).Should(Succeed())
+
+			list, err := client.ListGames(ctx)
+			Expect(err).Should(Succeed())
+			Expect(list.Games).To(HaveLen(1))
+			Expect(list.Games[0].ID).To(Equal(DefaultGameID))
+			Expect(list.Games[0].State).To(Equal(PlayerStateWagerTurn))
+
+			dump, err := client.DumpGame(ctx, DefaultGameID)
+			Expect(err).Should(Succeed())
+			Expect(dump).To(ContainSubstring("PlayerCards"))
+
+			adminGame, err := client.ForceRemovePlayer(ctx, DefaultGameID, "def")
+			Expect(err).Should(Succeed())
+			Expect(adminGame.Players).To(Equal([]string{"abc", "ghi"}))
+			Expect(adminGame.State).To(Equal(PlayerStateWaitingForPlayers))
+
+			_, err = client.StartRound(ctx, "abc")
+			Expect(err).Should(Succeed())
+			adminGame, err = client.ForceFinishRound(ctx, DefaultGameID)
+			Expect(err).Should(Succeed())
+			Expect(adminGame.State).To(Equal(PlayerStateWaitingForPlayers))
+
+			_, err = client.ForceFinishRound(ctx, DefaultGameID)
+			Expect(AsGameError(err).Code).To(Equal(ErrorCodeWrongGameState))
+			_, err = client.ResetGame(ctx, "nope")
+			Expect(AsGameError(err).Code).To(Equal(ErrorCodeNotFound))
+
+			adminGame, err = client.ResetGame(ctx, DefaultGameID)
+			Expect(err).Should(Succeed())
+			Expect(adminGame.Players).To(BeEmpty())
+		})
+
+		It("should show a broadcast at every table", func() {
+			_, client, stop := newTestServer()
+			defer stop()
+			client.AdminToken = testAdminToken
+
+			list, err := client.Broadcast(ctx, "closing in 10 minutes")
+			Expect(err).Should(Succeed())
+			Expect(list.Games[0].Announcement).To(Equal("closing in 10 minutes"))
+			pm, err := client.GetMyModel(ctx, "anyone")
+			Expect(err).Should(Succeed())
+			Expect(pm.Announcement).To(Equal("closing in 10 minutes"))
+
+			_, err = client.Broadcast(ctx, "")
+			Expect(err).Should(Succeed())
+			public, err := client.GetPublicModel(ctx)
+			Expect(err).Should(Succeed())
+			Expect(public.Announcement).To(BeEmpty())
+		})
+	})
+}
@@ -0,0 +1,173 @@
+package game
+
+import (
+	"time"
+)
+
+// RoundRecord is the outcome of a single finished round.
+type RoundRecord struct {
+	Guid           string
+	DeckType       DeckType
+	CardsPerPlayer int
+	TrumpSuit      string
+	PlayersOrder   []string
+	Wagers         map[string]int
+	HandsWon       map[string]int
+	Winners        []string
+	Tricks         []*Trick
+}
+
+func newRoundRecord(round *Round) *RoundRecord {
+	handsWon := round.HandsWon()
+	winners := []string{}
+	for _, player := range round.PlayersOrder {
+		if _, ok := handsWon[player]; !ok {
+			handsWon[player] = 0
+		}
+		if wager, ok := round.Wagers[player]; ok && wager == handsWon[player] {
+			winners = append(winners, player)
+		}
+	}
+	wagers := map[string]int{}
+	for player, wager := range round.Wagers {
+		wagers[player] = wager
+	}
+	return &RoundRecord{
+		Guid:           round.Guid,
+		DeckType:       round.Deck.DeckType(),
+		CardsPerPlayer: round.CardsPerPlayer,
+		TrumpSuit:      round.TrumpSuit,
+		PlayersOrder:   append([]string{}, round.PlayersOrder...),
+		Wagers:         wagers,
+		HandsWon:       handsWon,
+		Winners:        winners,
+		Tricks:         newTricks(round),
+	}
+}
+
+// GameRules describes how a game was played: which decks were used, and the sequence
+// of cards per player -- i.e. how far up (and back down) the river the players went.
+type GameRules struct {
+	DeckTypes      []DeckType
+	CardsPerPlayer []int
+}
+
+// GameSummary is what the archive listing shows for each completed game.
+type GameSummary struct {
+	Guid       string
+	Started    time.Time
+	Finished   time.Time
+	Players    []string
+	Scores     map[string]int
+	Rules      *GameRules
+	RoundCount int
+}
+
+// GameRecord is a completed game, including the outcome of every round.
+type GameRecord struct {
+	*GameSummary
+	Rounds []*RoundRecord
+}
+
+func newGameRecord(game *Game, finished time.Time) *GameRecord {
+	players := []string{}
+	playersSet := map[string]bool{}
+	scores := map[string]int{}
+	rules := &GameRules{DeckTypes: []DeckType{}, CardsPerPlayer: []int{}}
+	deckTypesSet := map[DeckType]bool{}
+	rounds := []*RoundRecord{}
+	for _, round := range game.FinishedRounds {
+		record := newRoundRecord(round)
+		for _, player := range record.PlayersOrder {
+			if !playersSet[player] {
+				playersSet[player] = true
+				players = append(players, player)
+				scores[player] = 0
+			}
+		}
+		for _, winner := range record.Winners {
+			scores[winner]++
+		}
+		if !deckTypesSet[record.DeckType] {
+			deckTypesSet[record.DeckType] = true
+			rules.DeckTypes = append(rules.DeckTypes, record.DeckType)
+		}
+		rules.CardsPerPlayer = append(rules.CardsPerPlayer, record.CardsPerPlayer)
+		rounds = append(rounds, record)
+	}
+	return &GameRecord{
+		GameSummary: &GameSummary{
+			Guid:       game.Guid,
+			Started:    game.Started,
+			Finished:   finished,
+			Players:    players,
+			Scores:     scores,
+			Rules:      rules,
+			RoundCount: len(rounds),
+		},
+		Rounds: rounds,
+	}
+}
+
+// ArchivePage is one page of the archive listing, most recently finished games first.
+type ArchivePage struct {
+	Games  []*GameSummary
+	Offset int
+	Limit  int
+	Total  int
+}
+
+// maxArchivedGames is how many finished games the archive keeps; once it's full, the oldest
+// games are dropped to make room
+const maxArchivedGames = 500
+
+// Archive is the record of finished games.  It's kept beside a game, rather than in it, so
+// that the history doesn't weigh down every copy of the game.
+type Archive struct {
+	Games []*GameRecord
+}
+
+func NewArchive() *Archive {
+	return &Archive{Games: []*GameRecord{}}
+}
+
+func (archive *Archive) add(record *GameRecord) {
+	archive.Games = append(archive.Games, record)
+	archive.trim()
+}
+
+// trim drops the oldest games beyond maxArchivedGames
+func (archive *Archive) trim() {
+	if excess := len(archive.Games) - maxArchivedGames; excess > 0 {
+		archive.Games = append([]*GameRecord{}, archive.Games[excess:]...)
+	}
+}
+
+func (archive *Archive) page(offset int, limit int) (*ArchivePage, error) {
+	if offset < 0 {
+		return nil, newGameError(ErrorCodeInvalidRequest, nil, "invalid offset %d, must be at least 0", offset)
+	}
+	if limit < 1 {
+		return nil, newGameError(ErrorCodeInvalidRequest, nil, "invalid limit %d, must be at least 1", limit)
+	}
+	summaries := []*GameSummary{}
+	total := len(archive.Games)
+	for i := total - 1 - offset; i >= 0 && len(summaries) < limit; i-- {
+		summaries = append(summaries, archive.Games[i].GameSummary)
+	}
+	return &ArchivePage{
+		Games:  summaries,
+		Offset: offset,
+		Limit:  limit,
+		Total:  total,
+	}, nil
+}
+
+func (archive *Archive) game(guid string) (*GameRecord, error) {
+	for _, record := range archive.Games {
+		if record.Guid == guid {
+			return record, nil
+		}
+	}
+	return nil, newGameError(ErrorCodeNotFound, nil, "archived game %s not found", guid)
+}
@@ -0,0 +1,97 @@
+package game
+
+import (
+	"fmt"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func RunArchiveTests() {
+	Describe("Archive", func() {
+		playRound := func(game *Game) {
+			Expect(game.startRound()).Should(Succeed())
+			// with a deterministic deck and 1 card per player, abc gets the 2 of clubs and def the 3 of clubs
+			Expect(game.makeWager(game.Players[0], 0)).Should(Succeed())
+			Expect(game.makeWager(game.Players[1], 0)).Should(Succeed())
+			Expect(game.playCard(game.Players[0], &Card{Suit: "Clubs", Number: "2"})).Should(Succeed())
+			Expect(game.playCard(game.Players[1], &Card{Suit: "Clubs", Number: "3"})).Should(Succeed())
+			Expect(game.finishRound()).Should(Succeed())
+		}
+
+		It("should not finish a game without any rounds", func() {
+			game := NewGame()
+			archive := NewArchive()
+			Expect(joinGame(game, "abc")).Should(Succeed())
+			Expect(game.finishGame(archive)).ShouldNot(Succeed())
+			Expect(archive.Games).To(BeEmpty())
+		})
+
+		It("should archive a finished game with scores and rules", func() {
+			game := NewGame()
+			archive := NewArchive()
+			game.Deck = NewDeterministicShuffleDeck()
+			Expect(joinGame(game, "abc")).Should(Succeed())
+			Expect(joinGame(game, "def")).Should(Succeed())
+			guid := game.Guid
+
+			playRound(game)
+			Expect(game.finishGame(archive)).Should(Succeed())
+
+			Expect(game.Guid).ToNot(Equal(guid))
+			Expect(game.FinishedRounds).To(BeEmpty())
+			Expect(game.Players).To(Equal([]string{"def", "abc"}))
+
+			record, err := archive.game(guid)
+			Expect(err).Should(Succeed())
+			Expect(record.Players).To(Equal([]string{"abc", "def"}))
+			Expect(record.Scores).To(Equal(map[string]int{"abc": 1, "def": 0}))
+			Expect(record.RoundCount).To(Equal(1))
+			Expect(record.Rules).To(Equal(&GameRules{DeckTypes: []DeckType{DeckTypeDeterministicStandard}, CardsPerPlayer: []int{1}}))
+			Expect(record.Rounds[0].HandsWon).To(Equal(map[string]int{"abc": 0, "def": 1}))
+			Expect(record.Rounds[0].Winners).To(Equal([]string{"abc"}))
+
+			_, err = archive.game("not-a-game")
+			Expect(err).ShouldNot(Succeed())
+		})
+
+		It("should page through games, most recent first", func() {
+			game := NewGame()
+			archive := NewArchive()
+			game.Deck = NewDeterministicShuffleDeck()
+			Expect(joinGame(game, "abc")).Should(Succeed())
+			Expect(joinGame(game, "def")).Should(Succeed())
+			guids := []string{}
+			for i := 0; i < 3; i++ {
+				guids = append(guids, game.Guid)
+				playRound(game)
+				Expect(game.finishGame(archive)).Should(Succeed())
+			}
+
+			page, err := archive.page(0, 2)
+			Expect(err).Should(Succeed())
+			Expect(page.Total).To(Equal(3))
+			Expect(page.Games).To(HaveLen(2))
+			Expect(page.Games[0].Guid).To(Equal(guids[2]))
+			Expect(page.Games[1].Guid).To(Equal(guids[1]))
+
+			page, err = archive.page(2, 2)
+			Expect(err).Should(Succeed())
+			Expect(page.Games).To(HaveLen(1))
+			Expect(page.Games[0].Guid).To(Equal(guids[0]))
+
+			_, err = archive.page(0, 0)
+			Expect(err).ShouldNot(Succeed())
+		})
+
+		It("should drop the oldest games once it's full", func() {
+			archive := NewArchive()
+			for i := 0; i < maxArchivedGames+2; i++ {
+				archive.add(&GameRecord{GameSummary: &GameSummary{Guid: fmt.Sprintf("game-%d", i)}})
+			}
+			Expect(archive.Games).To(HaveLen(maxArchivedGames))
+			Expect(archive.Games[0].Guid).To(Equal("game-2"))
+			_, err := archive.game("game-1")
+			Expect(err).ShouldNot(Succeed())
+		})
+	})
+}
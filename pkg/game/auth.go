@@ -0,0 +1,31 @@
+package game
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+)
+
+const bearerPrefix = "Bearer "
+
+// ErrUnauthorized is returned for requests whose admin credential is missing or wrong
+var ErrUnauthorized = newGameError(ErrorCodeUnauthorized, nil, "missing or invalid admin token")
+
+// hasCredential reports whether r is trying to authenticate at all
+func hasCredential(r *http.Request) bool {
+	return r.Header.Get("Authorization") != ""
+}
+
+// checkAdmin makes sure r carries adminToken as a bearer token.  With no admin token
+// configured, admin access is turned off, and every request is turned away.
+func checkAdmin(r *http.Request, adminToken string) error {
+	header := r.Header.Get("Authorization")
+	if adminToken == "" || !strings.HasPrefix(header, bearerPrefix) {
+		return ErrUnauthorized
+	}
+	token := strings.TrimPrefix(header, bearerPrefix)
+	if subtle.ConstantTimeCompare([]byte(token), []byte(adminToken)) != 1 {
+		return ErrUnauthorized
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package game
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// Cards can be written compactly as their number followed by their suit: "QH", "10S", "AS" --
+// or, with Unicode suit symbols, "Q♥", "10♠", "A♠".  Each suit is abbreviated by the shortest
+// prefix of its name that no other suit in the deck shares, so that any deck has a notation.
+
+var suitSymbols = map[string]string{
+	"Clubs":    "♣",
+	"Diamonds": "♦",
+	"Hearts":   "♥",
+	"Spades":   "♠",
+}
+
+// SuitSymbol is the Unicode symbol for suit, or the suit's name if it doesn't have one
+func SuitSymbol(suit string) string {
+	if symbol, ok := suitSymbols[suit]; ok {
+		return symbol
+	}
+	return suit
+}
+
+// notationDeck is the deck used for cards that come without one -- for example, in a
+// PlayerAction.  Every predefined deck uses its suits, and a subset of its numbers.
+var notationDeck Deck = NewStandardDeck()
+
+// Deck is the deck the player's game uses, for reading and writing its cards
+func (pm *PlayerModel) Deck() Deck {
+	deck, err := NewDeck(pm.Game.DeckType)
+	if err != nil {
+		return notationDeck
+	}
+	return deck
+}
+
+func suitAbbreviations(deck Deck) map[string]string {
+	suits := deck.Suits()
+	abbreviations := map[string]string{}
+	for _, suit := range suits {
+		length := 1
+		for ; length < len(suit); length++ {
+			prefix := strings.ToUpper(suit[:length])
+			unique := true
+			for _, other := range suits {
+				if other != suit && strings.HasPrefix(strings.ToUpper(other), prefix) {
+					unique = false
+					break
+				}
+			}
+			if unique {
+				break
+			}
+		}
+		abbreviations[suit] = strings.ToUpper(suit[:length])
+	}
+	return abbreviations
+}
+
+// FormatCard writes card in short notation, using deck's suits
+func FormatCard(deck Deck, card *Card) string {
+	if abbreviation, ok := suitAbbreviations(deck)[card.Suit]; ok {
+		return card.Number + abbreviation
+	}
+	return card.Number + card.Suit
+}
+
+// FormatCardUnicode writes card in short notation, with a symbol for its suit if it has one
+func FormatCardUnicode(deck Deck, card *Card) string {
+	if symbol, ok := suitSymbols[card.Suit]; ok {
+		return card.Number + symbol
+	}
+	return FormatCard(deck, card)
+}
+
+// FormatCards writes cards in short notation, separated by spaces
+func FormatCards(deck Deck, cards []*Card) string {
+	strs := []string{}
+	for _, card := range cards {
+		strs = append(strs, FormatCard(deck, card))
+	}
+	return strings.Join(strs, " ")
+}
+
+// ParseCard reads a card written in short notation -- with suit letters or symbols, in
+// either case -- checking that its suit and number are in deck.
+func ParseCard(deck Deck, text string) (*Card, error) {
+	trimmed := strings.ToUpper(strings.TrimSpace(text))
+	type suitMarker struct {
+		Suit   string
+		Marker string
+	}
+	markers := []*suitMarker{}
+	for suit, abbreviation := range suitAbbreviations(deck) {
+		markers = append(markers, &suitMarker{Suit: suit, Marker: abbreviation})
+		if symbol, ok := suitSymbols[suit]; ok {
+			markers = append(markers, &suitMarker{Suit: suit, Marker: symbol})
+		}
+	}
+	// longest first, so that a suit's abbreviation isn't mistaken for the end of a longer one
+	sort.Slice(markers, func(i, j int) bool {
+		return len(markers[i].Marker) > len(markers[j].Marker)
+	})
+	for _, marker := range markers {
+		if !strings.HasSuffix(trimmed, marker.Marker) {
+			continue
+		}
+		numberText := strings.TrimSuffix(trimmed, marker.Marker)
+		for _, number := range deck.Numbers() {
+			if strings.ToUpper(number) == numberText {
+				return &Card{Suit: marker.Suit, Number: number}, nil
+			}
+		}
+	}
+	return nil, newGameError(ErrorCodeInvalidCard, map[string]interface{}{"Card": text}, "unable to parse card %s", text)
+}
+
+// String writes a card in short notation, so that cards are easy to read in logs
+func (card *Card) String() string {
+	return FormatCard(notationDeck, card)
+}
+
+// UnmarshalJSON accepts cards either as objects, or as strings in short notation
+func (card *Card) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		parsed, err := ParseCard(notationDeck, text)
+		if err != nil {
+			return err
+		}
+		*card = *parsed
+		return nil
+	}
+	type cardJson Card
+	return json.Unmarshal(data, (*cardJson)(card))
+}
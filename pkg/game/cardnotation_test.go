@@ -0,0 +1,55 @@
+package game
+
+import (
+	"encoding/json"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func RunCardNotationTests() {
+	Describe("Card notation", func() {
+		deck := NewStandardDeck()
+
+		It("should format cards", func() {
+			Expect(FormatCard(deck, &Card{Suit: "Hearts", Number: "Q"})).To(Equal("QH"))
+			Expect(FormatCard(deck, &Card{Suit: "Spades", Number: "10"})).To(Equal("10S"))
+			Expect(FormatCardUnicode(deck, &Card{Suit: "Spades", Number: "A"})).To(Equal("A♠"))
+			Expect((&Card{Suit: "Diamonds", Number: "2"}).String()).To(Equal("2D"))
+			Expect(SuitSymbol("Hearts")).To(Equal("♥"))
+			Expect(SuitSymbol("Stars")).To(Equal("Stars"))
+		})
+
+		It("should parse what it formats, for every card in a deck", func() {
+			for _, card := range Cards(NewDoubleStandardDeck()) {
+				Expect(ParseCard(deck, FormatCard(deck, card))).To(Equal(card))
+				Expect(ParseCard(deck, FormatCardUnicode(deck, card))).To(Equal(card))
+			}
+		})
+
+		It("should parse in either case, and reject cards not in the deck", func() {
+			Expect(ParseCard(deck, "qh")).To(Equal(&Card{Suit: "Hearts", Number: "Q"}))
+			Expect(ParseCard(deck, " 10s ")).To(Equal(&Card{Suit: "Spades", Number: "10"}))
+
+			_, err := ParseCard(NewMiniDeckWithShuffle(NoShuffle), "10S")
+			Expect(AsGameError(err).Code).To(Equal(ErrorCodeInvalidCard))
+			_, err = ParseCard(deck, "QX")
+			Expect(AsGameError(err).Code).To(Equal(ErrorCodeInvalidCard))
+		})
+
+		It("should abbreviate suits that share a first letter", func() {
+			custom := NewSimpleDeck([]string{"1", "2"}, []string{"Stars", "Swords", "Moons"}, DeckTypeCustom, NoShuffle)
+			Expect(FormatCard(custom, &Card{Suit: "Stars", Number: "2"})).To(Equal("2ST"))
+			Expect(FormatCard(custom, &Card{Suit: "Moons", Number: "1"})).To(Equal("1M"))
+			Expect(ParseCard(custom, "1SW")).To(Equal(&Card{Suit: "Swords", Number: "1"}))
+		})
+
+		It("should unmarshal cards from short notation, or from objects", func() {
+			action := &PlayerAction{}
+			Expect(json.Unmarshal([]byte(`{"Me": "abc", "PlayCard": "KD"}`), action)).Should(Succeed())
+			Expect(action.PlayCard).To(Equal(&Card{Suit: "Diamonds", Number: "K"}))
+			Expect(json.Unmarshal([]byte(`{"Me": "abc", "PlayCard": {"Suit": "Clubs", "Number": "3"}}`), action)).Should(Succeed())
+			Expect(action.PlayCard).To(Equal(&Card{Suit: "Clubs", Number: "3"}))
+			Expect(json.Unmarshal([]byte(`{"Me": "abc", "PlayCard": "ZZ"}`), action)).ShouldNot(Succeed())
+		})
+	})
+}
@@ -1,9 +1,11 @@
 package game
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/go-resty/resty/v2"
-	"github.com/pkg/errors"
+	"net/url"
 	"time"
 )
 
@@ -11,6 +13,11 @@ type Client struct {
 	Host  string
 	Port  int
 	Resty *resty.Client
+	// AdminToken, if set, is sent with requests that need admin access
+	AdminToken string
+	// ExpectedVersion, if set, makes every action conditional on the game still being at that
+	// version; see AtVersion
+	ExpectedVersion *int
 }
 
 func NewClient(host string, port int) *Client {
@@ -21,25 +28,72 @@ func NewClient(host string, port int) *Client {
 	return &Client{Host: host, Port: port, Resty: restyClient}
 }
 
+// AtVersion is a copy of the client whose actions are only applied if the game is still at
+// version -- so that a player doesn't act on a stale view of the game.  If the game has moved
+// on, actions fail with ErrorCodeVersionConflict; see ConflictPlayerModel.
+func (client *Client) AtVersion(version int) *Client {
+	conditional := *client
+	conditional.ExpectedVersion = &version
+	return &conditional
+}
+
 func (client *Client) url(path string) string {
 	return fmt.Sprintf("http://%s:%d/%s", client.Host, client.Port, path)
 }
 
-func (client *Client) GetModel() (string, error) {
+// responseError turns an unsuccessful response into the GameError the server sent back --
+// or, if the server didn't send one, a GameError built from the status code.
+func responseError(url string, resp *resty.Response) error {
+	if resp.IsSuccess() {
+		return nil
+	}
+	gameError := &GameError{}
+	err := json.Unmarshal(resp.Body(), gameError)
+	if err == nil && gameError.Code != "" {
+		return gameError
+	}
+	code := ErrorCodeInternal
+	switch resp.StatusCode() {
+	case 404:
+		code = ErrorCodeNotFound
+	case statusClientClosedRequest:
+		code = ErrorCodeCancelled
+	case 503:
+		code = ErrorCodeUnavailable
+	case 504:
+		code = ErrorCodeTimeout
+	}
+	return newGameError(code, map[string]interface{}{"StatusCode": resp.StatusCode()}, "bad status code from %s: %d", url, resp.StatusCode())
+}
+
+// GetModel fetches the whole game, every player's cards included; it needs AdminToken.
+// Without one, the server would send back the public model instead, so it isn't asked.
+func (client *Client) GetModel(ctx context.Context) (string, error) {
+	if client.AdminToken == "" {
+		return "", newGameError(ErrorCodeUnauthorized, nil, "the whole game needs an admin token")
+	}
 	url := client.url("model")
-	resp, err := client.Resty.R(). /*.SetBody()*/ Get(url)
+	resp, err := client.Resty.R().SetContext(ctx).SetAuthToken(client.AdminToken).Get(url)
 	if err != nil {
-		return resp.String(), err
+		return "", err
 	}
-	if resp.StatusCode() < 200 || resp.StatusCode() > 299 {
-		return resp.String(), errors.New(fmt.Sprintf("bad status code from %s: %d", url, resp.StatusCode()))
+	return resp.String(), responseError(url, resp)
+}
+
+// GetPublicModel fetches what anyone can see of the game
+func (client *Client) GetPublicModel(ctx context.Context) (*PublicModel, error) {
+	url := client.url("model")
+	public := &PublicModel{}
+	resp, err := client.Resty.R().SetContext(ctx).SetResult(public).Get(url)
+	if err != nil {
+		return nil, err
 	}
-	return resp.String(), nil
+	return public, responseError(url, resp)
 }
 
-func (client *Client) postJson(path string, body interface{}, result interface{}) (string, error) {
+func (client *Client) postJson(ctx context.Context, path string, body interface{}, result interface{}) (string, error) {
 	url := client.url(path)
-	req := client.Resty.R().SetHeader("Content-Type", "application/json")
+	req := client.Resty.R().SetContext(ctx).SetHeader("Content-Type", "application/json")
 	if result != nil {
 		req = req.SetResult(result)
 	}
@@ -49,22 +103,160 @@ func (client *Client) postJson(path string, body interface{}, result interface{}
 
 	resp, err := req.Post(url)
 	if err != nil {
-		return resp.String(), err
+		return "", err
+	}
+	return resp.String(), responseError(url, resp)
+}
+
+// postAction sends an action, with a fresh idempotency key if it changes anything -- so that
+// if resty retries it, it still won't be applied more than once.  If the client has an
+// ExpectedVersion, the server will only apply the action at that version.
+func (client *Client) postAction(ctx context.Context, action *PlayerAction) (*PlayerModel, error) {
+	if action.GetModel == nil && action.IdempotencyKey == "" {
+		action.IdempotencyKey = NewGuid()
 	}
-	if resp.StatusCode() < 200 || resp.StatusCode() > 299 {
-		return resp.String(), errors.New(fmt.Sprintf("bad status code from %s: %d", url, resp.StatusCode()))
+	if action.GetModel == nil && action.ExpectedVersion == nil {
+		action.ExpectedVersion = client.ExpectedVersion
 	}
+	result := &PlayerModel{}
+	_, err := client.postJson(ctx, "action", action, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
 
-	return resp.String(), nil
+func (client *Client) GetMyModel(ctx context.Context, me string) (*PlayerModel, error) {
+	return client.postAction(ctx, &PlayerAction{Me: me, GetModel: &GetPlayerModelAction{}})
 }
 
-func (client *Client) postAction(action *PlayerAction) (*PlayerModel, error) {
-	result := &PlayerModel{}
-	_, err := client.postJson("action", action, result)
-	return result, err
+// Join adds me to the game.  The returned model is for the name the server actually used.
+func (client *Client) Join(ctx context.Context, me string) (*PlayerModel, error) {
+	return client.postAction(ctx, &PlayerAction{Me: me, Join: &JoinAction{}})
+}
+
+func (client *Client) RemovePlayer(ctx context.Context, me string, player string) (*PlayerModel, error) {
+	return client.postAction(ctx, &PlayerAction{Me: me, RemovePlayer: &RemovePlayerAction{Player: player}})
+}
+
+func (client *Client) SetCardsPerPlayer(ctx context.Context, me string, count int) (*PlayerModel, error) {
+	return client.postAction(ctx, &PlayerAction{Me: me, SetCardsPerPlayer: &SetCardsPerPlayerAction{Count: count}})
+}
+
+func (client *Client) SetDeckType(ctx context.Context, me string, deckType DeckType) (*PlayerModel, error) {
+	return client.postAction(ctx, &PlayerAction{Me: me, SetDeckType: &SetDeckTypePlayerAction{DeckType: deckType}})
+}
+
+func (client *Client) StartRound(ctx context.Context, me string) (*PlayerModel, error) {
+	return client.postAction(ctx, &PlayerAction{Me: me, StartRound: &StartRoundAction{}})
+}
+
+func (client *Client) MakeWager(ctx context.Context, me string, hands int) (*PlayerModel, error) {
+	return client.postAction(ctx, &PlayerAction{Me: me, MakeWager: &MakeWagerAction{Hands: hands}})
+}
+
+func (client *Client) PlayCard(ctx context.Context, me string, card *Card) (*PlayerModel, error) {
+	return client.postAction(ctx, &PlayerAction{Me: me, PlayCard: card})
+}
+
+func (client *Client) FinishRound(ctx context.Context, me string) (*PlayerModel, error) {
+	return client.postAction(ctx, &PlayerAction{Me: me, FinishRound: &FinishRoundAction{}})
+}
+
+func (client *Client) FinishGame(ctx context.Context, me string) (*PlayerModel, error) {
+	return client.postAction(ctx, &PlayerAction{Me: me, FinishGame: &FinishGameAction{}})
+}
+
+// adminRequest sends a request to the admin api, authenticated with AdminToken
+func (client *Client) adminRequest(ctx context.Context, method string, path string, body interface{}, result interface{}) (string, error) {
+	url := client.url(path)
+	req := client.Resty.R().SetContext(ctx).SetAuthToken(client.AdminToken)
+	if result != nil {
+		req = req.SetResult(result)
+	}
+	if body != nil {
+		req = req.SetHeader("Content-Type", "application/json").SetBody(body)
+	}
+	resp, err := req.Execute(method, url)
+	if err != nil {
+		return "", err
+	}
+	return resp.String(), responseError(url, resp)
 }
 
-func (client *Client) GetMyModel(me string) (*PlayerModel, error) {
-	body := &PlayerAction{Me: me, GetModel: &GetPlayerModelAction{}}
-	return client.postAction(body)
+func adminGamePath(id string, rest string) string {
+	return "admin/games/" + url.PathEscape(id) + rest
+}
+
+func (client *Client) ListGames(ctx context.Context) (*AdminGameList, error) {
+	list := &AdminGameList{}
+	_, err := client.adminRequest(ctx, "GET", "admin/games", nil, list)
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// DumpGame fetches the whole of one game's internal state
+func (client *Client) DumpGame(ctx context.Context, id string) (string, error) {
+	return client.adminRequest(ctx, "GET", adminGamePath(id, ""), nil, nil)
+}
+
+// ForceFinishRound finishes the game's current round, abandoning it if it isn't over yet
+func (client *Client) ForceFinishRound(ctx context.Context, id string) (*AdminGame, error) {
+	game := &AdminGame{}
+	_, err := client.adminRequest(ctx, "POST", adminGamePath(id, "/rounds/current/finish"), nil, game)
+	if err != nil {
+		return nil, err
+	}
+	return game, nil
+}
+
+// ForceRemovePlayer removes player from the game, even in the middle of a round
+func (client *Client) ForceRemovePlayer(ctx context.Context, id string, player string) (*AdminGame, error) {
+	game := &AdminGame{}
+	_, err := client.adminRequest(ctx, "DELETE", adminGamePath(id, "/players/"+url.PathEscape(player)), nil, game)
+	if err != nil {
+		return nil, err
+	}
+	return game, nil
+}
+
+func (client *Client) ResetGame(ctx context.Context, id string) (*AdminGame, error) {
+	game := &AdminGame{}
+	_, err := client.adminRequest(ctx, "POST", adminGamePath(id, "/reset"), nil, game)
+	if err != nil {
+		return nil, err
+	}
+	return game, nil
+}
+
+// Broadcast shows message at every table; an empty message clears it
+func (client *Client) Broadcast(ctx context.Context, message string) (*AdminGameList, error) {
+	list := &AdminGameList{}
+	_, err := client.adminRequest(ctx, "POST", "admin/broadcast", &AdminBroadcastRequest{Message: message}, list)
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// ConflictPlayerModel extracts the up-to-date PlayerModel sent back with a version conflict.
+func ConflictPlayerModel(err error) (*PlayerModel, bool) {
+	if err == nil {
+		return nil, false
+	}
+	gameError := AsGameError(err)
+	if gameError.Code != ErrorCodeVersionConflict {
+		return nil, false
+	}
+	bytes, marshalErr := json.Marshal(gameError.Details["PlayerModel"])
+	if marshalErr != nil {
+		return nil, false
+	}
+	pm := &PlayerModel{}
+	if json.Unmarshal(bytes, pm) != nil || pm.Game == nil {
+		return nil, false
+	}
+	return pm, true
 }
@@ -0,0 +1,139 @@
+package game
+
+import (
+	"context"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+)
+
+const testAdminToken = "test-admin-token"
+
+// newTestServer serves a new game over http, until stop is called
+func newTestServer() (*GameConcurrencyWrapper, *Client, func()) {
+	stopGame := make(chan struct{})
+	gcw := NewGameConcurrencyWrapper(NewGame(), NewArchive(), stopGame)
+	registry := NewGameRegistry()
+	registry.Add(DefaultGameID, gcw)
+	server := httptest.NewServer(NewServer(&Config{AdminToken: testAdminToken}, registry, nil).Handler())
+	serverUrl, err := url.Parse(server.URL)
+	Expect(err).Should(Succeed())
+	port, err := strconv.Atoi(serverUrl.Port())
+	Expect(err).Should(Succeed())
+	return gcw, NewClient(serverUrl.Hostname(), port), func() {
+		server.Close()
+		close(stopGame)
+	}
+}
+
+func RunClientTests() {
+	Describe("Client", func() {
+		ctx := context.Background()
+
+		It("should drive a game, and decode errors", func() {
+			_, client, stop := newTestServer()
+			defer stop()
+
+			pm, err := client.Join(ctx, "abc")
+			Expect(err).Should(Succeed())
+			Expect(pm.Me).To(Equal("abc"))
+			_, err = client.Join(ctx, "def")
+			Expect(err).Should(Succeed())
+			_, err = client.SetDeckType(ctx, "abc", DeckTypeStandard)
+			Expect(err).Should(Succeed())
+			_, err = client.SetCardsPerPlayer(ctx, "abc", 1)
+			Expect(err).Should(Succeed())
+
+			_, err = client.MakeWager(ctx, "abc", 0)
+			Expect(AsGameError(err).Code).To(Equal(ErrorCodeWrongGameState))
+
+			pm, err = client.StartRound(ctx, "abc")
+			Expect(err).Should(Succeed())
+			Expect(pm.State).To(Equal(PlayerStateWagerTurn))
+
+			_, err = client.MakeWager(ctx, "def", 0)
+			gameError := AsGameError(err)
+			Expect(gameError.Code).To(Equal(ErrorCodeNotYourTurn))
+			Expect(gameError.Details["Expected"]).To(Equal("abc"))
+
+			pm, err = client.MakeWager(ctx, "abc", 0)
+			Expect(err).Should(Succeed())
+			pm, err = client.MakeWager(ctx, "def", 0)
+			Expect(err).Should(Succeed())
+			Expect(pm.LegalCards).To(BeNil())
+
+			for _, player := range []string{"abc", "def"} {
+				pm, err = client.GetMyModel(ctx, player)
+				Expect(err).Should(Succeed())
+				pm, err = client.PlayCard(ctx, player, pm.LegalCards[0])
+				Expect(err).Should(Succeed())
+			}
+			pm, err = client.FinishRound(ctx, "abc")
+			Expect(err).Should(Succeed())
+			Expect(pm.State).To(Equal(PlayerStateWaitingForPlayers))
+
+			pm, err = client.RemovePlayer(ctx, "abc", "def")
+			Expect(err).Should(Succeed())
+			Expect(pm.Game.Players).To(Equal([]string{"abc"}))
+		})
+
+		It("should send back the current model on a version conflict", func() {
+			_, client, stop := newTestServer()
+			defer stop()
+			stale, err := client.Join(ctx, "abc")
+			Expect(err).Should(Succeed())
+			_, err = client.Join(ctx, "def")
+			Expect(err).Should(Succeed())
+
+			_, err = client.AtVersion(stale.Version).SetCardsPerPlayer(ctx, "abc", 3)
+			Expect(AsGameError(err).Code).To(Equal(ErrorCodeVersionConflict))
+			current, ok := ConflictPlayerModel(err)
+			Expect(ok).To(BeTrue())
+			Expect(current.Game.Players).To(Equal([]string{"abc", "def"}))
+
+			pm, err := client.AtVersion(current.Version).SetCardsPerPlayer(ctx, "abc", 3)
+			Expect(err).Should(Succeed())
+			Expect(pm.Game.CardsPerPlayer).To(Equal(3))
+			Expect(pm.Version).To(Equal(current.Version + 1))
+		})
+
+		It("should only give admins the whole game", func() {
+			_, client, stop := newTestServer()
+			defer stop()
+			for _, player := range []string{"abc", "def"} {
+				_, err := client.Join(ctx, player)
+				Expect(err).Should(Succeed())
+			}
+			_, err := client.StartRound(ctx, "abc")
+			Expect(err).Should(Succeed())
+
+			public, err := client.GetPublicModel(ctx)
+			Expect(err).Should(Succeed())
+			Expect(public.State).To(Equal(PlayerStateWagerTurn))
+			Expect(public.Status.PlayerStatuses).To(HaveLen(2))
+
+			_, err = client.GetModel(ctx)
+			Expect(AsGameError(err).Code).To(Equal(ErrorCodeUnauthorized))
+
+			client.AdminToken = "wrong"
+			_, err = client.GetModel(ctx)
+			Expect(AsGameError(err).Code).To(Equal(ErrorCodeUnauthorized))
+
+			client.AdminToken = testAdminToken
+			model, err := client.GetModel(ctx)
+			Expect(err).Should(Succeed())
+			Expect(model).To(ContainSubstring("PlayerCards"))
+		})
+
+		It("should give up when the context is cancelled", func() {
+			_, client, stop := newTestServer()
+			defer stop()
+			cancelled, cancel := context.WithCancel(ctx)
+			cancel()
+			_, err := client.GetMyModel(cancelled, "abc")
+			Expect(err).ShouldNot(Succeed())
+		})
+	})
+}
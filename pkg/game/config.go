@@ -9,9 +9,30 @@ import (
 type Config struct {
 	LogLevel string
 
+	// UIDirectory, if set, is served instead of the ui built into the server -- for working on
+	// the ui without rebuilding the server
 	UIDirectory string
 
 	Port int
+
+	// TCPPort is where the line-oriented text protocol is served; leave it at 0 to turn it off
+	TCPPort int
+
+	// GRPCPort is where the gRPC service is served; leave it at 0 to turn it off
+	GRPCPort int
+
+	// AdminToken guards admin-only endpoints, which need an "Authorization: Bearer <AdminToken>"
+	// header.  Leave it empty to turn them off.  It can also be set with the ADMIN_TOKEN
+	// environment variable, to keep it out of the config file.
+	AdminToken string
+
+	// StateFile is where the game is saved on shutdown and restored from on startup.  Leave it
+	// empty to start with a new game every time.
+	StateFile string
+
+	// ArchiveFile is where finished games are saved on shutdown and restored from on startup.
+	// Leave it empty to start with an empty archive every time.
+	ArchiveFile string
 }
 
 // GetLogLevel ...
@@ -24,7 +45,11 @@ func GetConfig(configPath string) (*Config, error) {
 	var config *Config
 
 	viper.SetConfigFile(configPath)
-	err := viper.ReadInConfig()
+	err := viper.BindEnv("AdminToken", "ADMIN_TOKEN")
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to bind environment variable ADMIN_TOKEN")
+	}
+	err = viper.ReadInConfig()
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to ReadInConfig at %s", configPath)
 	}
@@ -114,9 +114,13 @@ func NewMiniDeckWithShuffle(shuffle shuffler) *SimpleDeck {
 }
 
 func NewStandardDeckWithShuffle(shuffle shuffler) *SimpleDeck {
+	return newStandardDeck(DeckTypeStandard, shuffle)
+}
+
+func newStandardDeck(deckType DeckType, shuffle shuffler) *SimpleDeck {
 	numbers := []string{"2", "3", "4", "5", "6", "7", "8", "9", "10", "J", "Q", "K", "A"}
 	suits := []string{"Clubs", "Diamonds", "Hearts", "Spades"}
-	return NewSimpleDeck(numbers, suits, DeckTypeStandard, shuffle)
+	return NewSimpleDeck(numbers, suits, deckType, shuffle)
 }
 
 func NewStandardDeck() *SimpleDeck {
@@ -124,7 +128,24 @@ func NewStandardDeck() *SimpleDeck {
 }
 
 func NewDeterministicShuffleDeck() *SimpleDeck {
-	return NewStandardDeckWithShuffle(NoShuffle)
+	return newStandardDeck(DeckTypeDeterministicStandard, NoShuffle)
+}
+
+// NewDeck builds a fresh deck of one of the predefined types.
+func NewDeck(deckType DeckType) (Deck, error) {
+	switch deckType {
+	case DeckTypeMini:
+		return NewMiniDeckWithShuffle(RandomShuffle), nil
+	case DeckTypeDoubleMini:
+		return NewDoubleDeck(NewMiniDeckWithShuffle(RandomShuffle), DeckTypeDoubleMini), nil
+	case DeckTypeStandard:
+		return NewStandardDeck(), nil
+	case DeckTypeDoubleStandard:
+		return NewDoubleStandardDeck(), nil
+	case DeckTypeDeterministicStandard:
+		return NewDeterministicShuffleDeck(), nil
+	}
+	return nil, errors.New(fmt.Sprintf("unable to build deck of type %s", deckType))
 }
 
 func (sd *SimpleDeck) Suits() []string {
@@ -248,11 +269,11 @@ const (
 func (d DeckType) JSONString() string {
 	switch d {
 	case DeckTypeCustom:
-		return "DeckTypeCustom"
+		return "Custom"
 	case DeckTypeMini:
-		return "DeckTypeMini"
+		return "Mini"
 	case DeckTypeDoubleMini:
-		return "DeckTypeDoubleMini"
+		return "DoubleMini"
 	case DeckTypeStandard:
 		return "Standard"
 	case DeckTypeDoubleStandard:
@@ -0,0 +1,124 @@
+package game
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"net/http"
+)
+
+// ErrorCode is a machine-readable reason for an action failing, so that clients can tell
+// apart -- for example -- a move the rules don't allow, from a request that makes no sense,
+// from something unexpected going wrong.
+type ErrorCode string
+
+const (
+	// violations of the rules of the game
+	ErrorCodeNotYourTurn            ErrorCode = "NotYourTurn"
+	ErrorCodeMustFollowSuit         ErrorCode = "MustFollowSuit"
+	ErrorCodeCardNotInHand          ErrorCode = "CardNotInHand"
+	ErrorCodeDealerWagerRestriction ErrorCode = "DealerWagerRestriction"
+	ErrorCodeInvalidWager           ErrorCode = "InvalidWager"
+	ErrorCodeTooFewPlayers          ErrorCode = "TooFewPlayers"
+	ErrorCodeTooManyCardsPerPlayer  ErrorCode = "TooManyCardsPerPlayer"
+	ErrorCodeNoRoundsPlayed         ErrorCode = "NoRoundsPlayed"
+	// the action doesn't make sense right now
+	ErrorCodeWrongGameState       ErrorCode = "WrongGameState"
+	ErrorCodeWrongRoundState      ErrorCode = "WrongRoundState"
+	ErrorCodePlayerAlreadyPresent ErrorCode = "PlayerAlreadyPresent"
+	ErrorCodeVersionConflict      ErrorCode = "VersionConflict"
+	// the request doesn't make sense
+	ErrorCodeUnknownPlayer  ErrorCode = "UnknownPlayer"
+	ErrorCodeInvalidName    ErrorCode = "InvalidName"
+	ErrorCodeInvalidDeck    ErrorCode = "InvalidDeckType"
+	ErrorCodeInvalidCard    ErrorCode = "InvalidCard"
+	ErrorCodeNotFound       ErrorCode = "NotFound"
+	ErrorCodeInvalidRequest ErrorCode = "InvalidRequest"
+	ErrorCodeUnauthorized   ErrorCode = "Unauthorized"
+	// the server couldn't handle the request -- or the client gave up on it
+	ErrorCodeTimeout     ErrorCode = "Timeout"
+	ErrorCodeCancelled   ErrorCode = "Cancelled"
+	ErrorCodeUnavailable ErrorCode = "Unavailable"
+	ErrorCodeInternal    ErrorCode = "Internal"
+)
+
+// statusClientClosedRequest is the unofficial, but widely used, status for a request that the
+// client gave up on before it could be answered
+const statusClientClosedRequest = 499
+
+func (code ErrorCode) HTTPStatus() int {
+	switch code {
+	case ErrorCodeWrongGameState, ErrorCodeWrongRoundState, ErrorCodePlayerAlreadyPresent, ErrorCodeVersionConflict:
+		return http.StatusConflict
+	case ErrorCodeUnknownPlayer, ErrorCodeNotFound:
+		return http.StatusNotFound
+	case ErrorCodeUnauthorized:
+		return http.StatusUnauthorized
+	case ErrorCodeTimeout:
+		return http.StatusGatewayTimeout
+	case ErrorCodeCancelled:
+		return statusClientClosedRequest
+	case ErrorCodeUnavailable:
+		return http.StatusServiceUnavailable
+	case ErrorCodeInternal:
+		return http.StatusInternalServerError
+	}
+	return http.StatusBadRequest
+}
+
+// GRPCCode is the gRPC equivalent of HTTPStatus
+func (code ErrorCode) GRPCCode() codes.Code {
+	switch code {
+	case ErrorCodeVersionConflict:
+		return codes.Aborted
+	case ErrorCodeWrongGameState, ErrorCodeWrongRoundState:
+		return codes.FailedPrecondition
+	case ErrorCodePlayerAlreadyPresent:
+		return codes.AlreadyExists
+	case ErrorCodeUnknownPlayer, ErrorCodeNotFound:
+		return codes.NotFound
+	case ErrorCodeUnauthorized:
+		return codes.Unauthenticated
+	case ErrorCodeTimeout:
+		return codes.DeadlineExceeded
+	case ErrorCodeCancelled:
+		return codes.Canceled
+	case ErrorCodeUnavailable:
+		return codes.Unavailable
+	case ErrorCodeInternal:
+		return codes.Internal
+	}
+	return codes.InvalidArgument
+}
+
+// GameError is the error returned for any action that fails, and is what's sent back to clients.
+type GameError struct {
+	Code    ErrorCode
+	Message string
+	Details map[string]interface{}
+}
+
+func newGameError(code ErrorCode, details map[string]interface{}, format string, args ...interface{}) *GameError {
+	return &GameError{
+		Code:    code,
+		Message: fmt.Sprintf(format, args...),
+		Details: details,
+	}
+}
+
+func (ge *GameError) Error() string {
+	return ge.Message
+}
+
+// AsGameError finds the GameError that caused err; anything else is treated as an internal error.
+func AsGameError(err error) *GameError {
+	var gameError *GameError
+	if errors.As(err, &gameError) {
+		return gameError
+	}
+	return &GameError{Code: ErrorCodeInternal, Message: err.Error()}
+}
+
+func wrongGameStateError(action string, state GameState) *GameError {
+	return newGameError(ErrorCodeWrongGameState, map[string]interface{}{"State": state}, "can't %s, in state %s", action, state.String())
+}
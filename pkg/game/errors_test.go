@@ -0,0 +1,77 @@
+package game
+
+import (
+	"context"
+	"encoding/json"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"net/http/httptest"
+)
+
+func RunErrorTests() {
+	Describe("Errors", func() {
+		codeOf := func(err error) ErrorCode {
+			Expect(err).ShouldNot(Succeed())
+			return AsGameError(err).Code
+		}
+
+		It("should distinguish between different rule violations", func() {
+			round := NewRound([]string{"abc", "def"}, NewDeterministicShuffleDeck(), 2)
+			Expect(codeOf(round.Wager("def", 1))).To(Equal(ErrorCodeNotYourTurn))
+			Expect(codeOf(round.Wager("abc", 3))).To(Equal(ErrorCodeInvalidWager))
+			Expect(codeOf(round.Wager("abc", -1))).To(Equal(ErrorCodeInvalidWager))
+			Expect(codeOf(round.PlayCard("abc", &Card{Suit: "Clubs", Number: "2"}))).To(Equal(ErrorCodeWrongRoundState))
+			Expect(round.Wager("abc", 1)).Should(Succeed())
+			Expect(codeOf(round.Wager("def", 1))).To(Equal(ErrorCodeDealerWagerRestriction))
+			Expect(round.Wager("def", 0)).Should(Succeed())
+
+			// abc has the 2 and 4 of clubs, def the 3 and 5
+			Expect(codeOf(round.PlayCard("abc", &Card{Suit: "Clubs", Number: "3"}))).To(Equal(ErrorCodeCardNotInHand))
+			Expect(codeOf(round.PlayCard("def", &Card{Suit: "Clubs", Number: "3"}))).To(Equal(ErrorCodeNotYourTurn))
+		})
+
+		It("should report follow-suit violations", func() {
+			round := NewRound([]string{"abc", "def"}, NewDeterministicShuffleDeck(), 14)
+			Expect(round.Wager("abc", 1)).Should(Succeed())
+			Expect(round.Wager("def", 1)).Should(Succeed())
+			Expect(round.PlayCard("abc", &Card{Suit: "Clubs", Number: "2"})).Should(Succeed())
+			Expect(codeOf(round.PlayCard("def", &Card{Suit: "Diamonds", Number: "2"}))).To(Equal(ErrorCodeMustFollowSuit))
+		})
+
+		It("should report game state violations", func() {
+			game := NewGame()
+			Expect(codeOf(game.finishRound())).To(Equal(ErrorCodeWrongGameState))
+			Expect(codeOf(game.removePlayer("abc"))).To(Equal(ErrorCodeUnknownPlayer))
+			Expect(codeOf(game.startRound())).To(Equal(ErrorCodeTooFewPlayers))
+			_, err := game.join("")
+			Expect(codeOf(err)).To(Equal(ErrorCodeInvalidName))
+		})
+
+		It("should treat unrecognized errors as internal", func() {
+			Expect(codeOf(errors.New("oops"))).To(Equal(ErrorCodeInternal))
+			Expect(codeOf(errors.Wrapf(ErrTimeout, "waiting"))).To(Equal(ErrorCodeTimeout))
+		})
+
+		It("should report cancelled actions as such, rather than as internal errors", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			Expect(codeOf(contextError(ctx))).To(Equal(ErrorCodeCancelled))
+			Expect(ErrorCodeCancelled.HTTPStatus()).To(Equal(statusClientClosedRequest))
+			Expect(ErrorCodeCancelled.GRPCCode()).To(Equal(codes.Canceled))
+		})
+
+		It("should send errors as json with a matching status code", func() {
+			recorder := httptest.NewRecorder()
+			writeError(recorder, NewRound([]string{"abc", "def"}, NewStandardDeck(), 1).Wager("def", 0))
+
+			Expect(recorder.Code).To(Equal(400))
+			gameError := &GameError{}
+			Expect(json.Unmarshal(recorder.Body.Bytes(), gameError)).Should(Succeed())
+			Expect(gameError.Code).To(Equal(ErrorCodeNotYourTurn))
+			Expect(gameError.Message).To(Equal("it is player abc's turn to wager, but got def"))
+			Expect(gameError.Details).To(Equal(map[string]interface{}{"Expected": "abc", "Player": "def"}))
+		})
+	})
+}
@@ -1,42 +1,161 @@
 package game
 
 import (
+	"context"
 	"fmt"
+	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	log "github.com/sirupsen/logrus"
-	"net/http"
+	"io/fs"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 )
 
-func doOrDie(err error) {
-	if err != nil {
-		log.Fatalf("unable to continue: %+v", err)
-	}
+const shutdownTimeout = 20 * time.Second
+
+// stoppable is a server that Run shuts down on its way out
+type stoppable struct {
+	name     string
+	shutdown func(ctx context.Context) error
 }
 
-func Run(configPath string) {
+// Run serves the game described by the config at configPath, and ui, until it's told to stop --
+// or a server fails.  Errors starting up, or that stop a server early, are returned.
+func Run(configPath string, ui fs.FS) error {
 	config, err := GetConfig(configPath)
-	doOrDie(err)
+	if err != nil {
+		return err
+	}
 
 	logLevel, err := config.GetLogLevel()
-	doOrDie(err)
+	if err != nil {
+		return errors.Wrapf(err, "invalid log level %s", config.LogLevel)
+	}
 	log.SetLevel(logLevel)
 
 	prometheus.Unregister(prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}))
 	prometheus.Unregister(prometheus.NewGoCollector())
 
-	stop := make(chan struct{})
+	// servers report errors that stop them early here, and are shut down -- last started,
+	// first stopped -- on the way out
+	serveErrors := make(chan error, 3)
+	var servers []*stoppable
+	serve := func(name string, listener net.Listener, serve func(net.Listener) error, shutdown func(context.Context) error) {
+		servers = append(servers, &stoppable{name: name, shutdown: shutdown})
+		log.Infof("serving %s on %s", name, listener.Addr())
+		go func() {
+			if err := serve(listener); err != nil {
+				serveErrors <- errors.WithMessagef(err, "%s server stopped", name)
+			}
+		}()
+	}
+	shutdown := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		for i := len(servers) - 1; i >= 0; i-- {
+			if err := servers[i].shutdown(ctx); err != nil {
+				log.Errorf("unable to cleanly shut down %s server: %+v", servers[i].name, err)
+			}
+		}
+	}
+
+	// serve health checks while the game loads; until it has, the server isn't ready
+	registry := NewGameRegistry()
+	server := NewServer(config, registry, ui)
+	addr := fmt.Sprintf(":%d", config.Port)
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return errors.Wrapf(err, "unable to listen on %s", addr)
+	}
+	serve("http", listener, server.Serve, server.Shutdown)
+
 	game := NewGame()
-	gcw := NewGameConcurrencyWrapper(game, stop)
+	if config.StateFile != "" {
+		game, err = LoadGame(config.StateFile)
+		if err != nil {
+			shutdown()
+			return err
+		}
+	}
 
-	SetupHTTPServer(config.UIDirectory, gcw)
+	archive := NewArchive()
+	if config.ArchiveFile != "" {
+		archive, err = LoadArchive(config.ArchiveFile)
+		if err != nil {
+			shutdown()
+			return err
+		}
+	}
 
-	addr := fmt.Sprintf(":%d", config.Port)
-	log.Infof("serving on %s", addr)
-	go func() {
-		http.ListenAndServe(addr, nil)
-	}()
+	stop := make(chan struct{})
+	gcw := NewGameConcurrencyWrapper(game, archive, stop)
+	registry.Add(DefaultGameID, gcw)
+
+	if config.TCPPort != 0 {
+		tcpAddr := fmt.Sprintf(":%d", config.TCPPort)
+		listener, err := net.Listen("tcp", tcpAddr)
+		if err != nil {
+			shutdown()
+			close(stop)
+			return errors.Wrapf(err, "unable to listen on %s", tcpAddr)
+		}
+		tcpServer := NewTCPServer(gcw)
+		serve("text protocol", listener, tcpServer.Serve, tcpServer.Shutdown)
+	}
 
-	log.Infof("instantiated game with concurrency wrapper: \n%s\n", gcw.GetModel())
+	if config.GRPCPort != 0 {
+		grpcAddr := fmt.Sprintf(":%d", config.GRPCPort)
+		listener, err := net.Listen("tcp", grpcAddr)
+		if err != nil {
+			shutdown()
+			close(stop)
+			return errors.Wrapf(err, "unable to listen on %s", grpcAddr)
+		}
+		grpcServer := NewGRPCServer(gcw)
+		serve("grpc", listener, grpcServer.Serve, grpcServer.Shutdown)
+	}
 
-	<-stop
+	model, err := gcw.GetModel()
+	if err != nil {
+		log.Errorf("unable to get model: %+v", err)
+	} else {
+		log.Infof("instantiated game with concurrency wrapper: \n%s\n", model)
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, syscall.SIGINT)
+	defer signal.Stop(signals)
+	var serveErr error
+	select {
+	case sig := <-signals:
+		log.Infof("received signal %s, shutting down", sig)
+	case serveErr = <-serveErrors:
+		log.Errorf("shutting down: %+v", serveErr)
+	}
+
+	// stop accepting requests, and wait for in-flight requests -- and therefore their actions -- to finish
+	shutdown()
+
+	close(stop)
+	<-gcw.Stopped
+
+	if config.StateFile != "" {
+		err = SaveGame(config.StateFile, gcw.Game)
+		if err != nil {
+			return err
+		}
+		log.Infof("saved game state to %s", config.StateFile)
+	}
+	if config.ArchiveFile != "" {
+		err = SaveArchive(config.ArchiveFile, gcw.Archive)
+		if err != nil {
+			return err
+		}
+		log.Infof("saved archive to %s", config.ArchiveFile)
+	}
+	log.Infof("shutdown complete")
+	return serveErr
 }
@@ -1,9 +1,11 @@
 package game
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
+	"time"
 )
 
 type GameState int
@@ -32,7 +34,42 @@ func (g GameState) MarshalText() (text []byte, err error) {
 	return []byte(g.String()), nil
 }
 
+func parseGameState(text string) (GameState, error) {
+	switch text {
+	case "GameStateSetup":
+		return GameStateSetup, nil
+	case "GameStateRoundInProgress":
+		return GameStateRoundInProgress, nil
+	}
+	return GameStateSetup, errors.New(fmt.Sprintf("unable to parse game state %s", text))
+}
+
+func (g *GameState) UnmarshalJSON(data []byte) error {
+	var str string
+	err := json.Unmarshal(data, &str)
+	if err != nil {
+		return err
+	}
+	state, err := parseGameState(str)
+	if err != nil {
+		return err
+	}
+	*g = state
+	return nil
+}
+
+func (g *GameState) UnmarshalText(text []byte) (err error) {
+	state, err := parseGameState(string(text))
+	if err != nil {
+		return err
+	}
+	*g = state
+	return nil
+}
+
 type Game struct {
+	Guid           string
+	Started        time.Time
 	Players        []string
 	PlayersSet     map[string]bool
 	Deck           Deck
@@ -40,10 +77,16 @@ type Game struct {
 	FinishedRounds []*Round
 	CurrentRound   *Round
 	State          GameState
+	// Version goes up by one with every change to the game
+	Version int
+	// Announcement is a message from the admins, shown to every player
+	Announcement string
 }
 
 func NewGame() *Game {
 	game := &Game{
+		Guid:           NewGuid(),
+		Started:        time.Now(),
 		Players:        []string{},
 		PlayersSet:     map[string]bool{},
 		Deck:           NewStandardDeck(),
@@ -59,9 +102,9 @@ func NewGame() *Game {
 
 func (game *Game) addPlayer(player string) error {
 	if game.State != GameStateSetup {
-		return errors.New(fmt.Sprintf("can't add player %s, in state %s", player, game.State.String()))
+		return wrongGameStateError(fmt.Sprintf("add player %s", player), game.State)
 	} else if game.PlayersSet[player] {
-		return errors.New(fmt.Sprintf("can't add player %s, already present", player))
+		return newGameError(ErrorCodePlayerAlreadyPresent, map[string]interface{}{"Player": player}, "can't add player %s, already present", player)
 	} else {
 		game.Players = append(game.Players, player)
 		game.PlayersSet[player] = true
@@ -75,7 +118,7 @@ func (game *Game) addPlayer(player string) error {
 
 func (game *Game) join(player string) (string, error) {
 	if player == "" {
-		return "", errors.New("invalid name: empty")
+		return "", newGameError(ErrorCodeInvalidName, nil, "invalid name: empty")
 	}
 	if len(player) > 20 {
 		// just take the first 20 characters so as not to get overwhelmed by excessively long names
@@ -88,16 +131,16 @@ func (game *Game) join(player string) (string, error) {
 		return player, nil
 	}
 	if game.State != GameStateSetup {
-		return "", errors.New(fmt.Sprintf("can't join as %s, in state %s", player, game.State.String()))
+		return "", wrongGameStateError(fmt.Sprintf("join as %s", player), game.State)
 	}
 	return player, game.addPlayer(player)
 }
 
 func (game *Game) removePlayer(player string) error {
 	if game.State != GameStateSetup {
-		return errors.New(fmt.Sprintf("can't remove player, in state %s", game.State.String()))
+		return wrongGameStateError("remove player", game.State)
 	} else if !game.PlayersSet[player] {
-		return errors.New(fmt.Sprintf("can't remove player %s, not present", player))
+		return newGameError(ErrorCodeUnknownPlayer, map[string]interface{}{"Player": player}, "can't remove player %s, not present", player)
 	} else {
 		delete(game.PlayersSet, player)
 		players := []string{}
@@ -115,13 +158,21 @@ func (game *Game) playerModel(player string) *PlayerModel {
 	return newPlayerModel(game, player)
 }
 
+func (game *Game) finishedRounds() []*RoundRecord {
+	records := []*RoundRecord{}
+	for _, round := range game.FinishedRounds {
+		records = append(records, newRoundRecord(round))
+	}
+	return records
+}
+
 func (game *Game) setCardsPerPlayer(count int) error {
 	if game.State != GameStateSetup {
-		return errors.New(fmt.Sprintf("can't set cards per player, in state %s", game.State.String()))
+		return wrongGameStateError("set cards per player", game.State)
 	}
 	maxCardsPerPlayer := len(Cards(game.Deck)) / len(game.Players)
 	if count > maxCardsPerPlayer {
-		return errors.New(fmt.Sprintf("requested cardsPerPlayer of %d, which is greater than the maxCardsPerPlayer of %d", count, maxCardsPerPlayer))
+		return newGameError(ErrorCodeTooManyCardsPerPlayer, map[string]interface{}{"Requested": count, "Max": maxCardsPerPlayer}, "requested cardsPerPlayer of %d, which is greater than the maxCardsPerPlayer of %d", count, maxCardsPerPlayer)
 	}
 	game.CardsPerPlayer = count
 	return nil
@@ -129,7 +180,7 @@ func (game *Game) setCardsPerPlayer(count int) error {
 
 func (game *Game) setDeckType(deckType DeckType) error {
 	if game.State != GameStateSetup {
-		return errors.New(fmt.Sprintf("can't set deck type, in state %s", game.State.String()))
+		return wrongGameStateError("set deck type", game.State)
 	}
 	switch deckType {
 	case DeckTypeStandard:
@@ -139,18 +190,18 @@ func (game *Game) setDeckType(deckType DeckType) error {
 	case DeckTypeDeterministicStandard:
 		game.Deck = NewDeterministicShuffleDeck()
 	default:
-		return errors.New(fmt.Sprintf("invalid deck type %s", deckType))
+		return newGameError(ErrorCodeInvalidDeck, map[string]interface{}{"DeckType": deckType}, "invalid deck type %s", deckType)
 	}
 	return nil
 }
 
 func (game *Game) startRound() error {
 	if game.State != GameStateSetup {
-		return errors.New(fmt.Sprintf("can't start round, in state %s", game.State.String()))
+		return wrongGameStateError("start round", game.State)
 	}
 	playerCount := len(game.Players)
 	if playerCount < 2 {
-		return errors.New(fmt.Sprintf("can't start game with fewer than 2 players, found %d", playerCount))
+		return newGameError(ErrorCodeTooFewPlayers, map[string]interface{}{"Players": playerCount}, "can't start game with fewer than 2 players, found %d", playerCount)
 	}
 	players := append([]string{}, game.Players...)
 	game.CurrentRound = NewRound(players, game.Deck, game.CardsPerPlayer)
@@ -160,7 +211,7 @@ func (game *Game) startRound() error {
 
 func (game *Game) finishRound() error {
 	if game.State != GameStateRoundInProgress {
-		return errors.New(fmt.Sprintf("can't finish round, in state %s", game.State.String()))
+		return wrongGameStateError("finish round", game.State)
 	} else {
 		game.FinishedRounds = append(game.FinishedRounds, game.CurrentRound)
 		game.CurrentRound = nil
@@ -171,16 +222,76 @@ func (game *Game) finishRound() error {
 	}
 }
 
+// finishGame adds the rounds played so far to archive as a completed game, and starts a new
+// game with the same players.
+func (game *Game) finishGame(archive *Archive) error {
+	if game.State != GameStateSetup {
+		return wrongGameStateError("finish game", game.State)
+	}
+	if len(game.FinishedRounds) == 0 {
+		return newGameError(ErrorCodeNoRoundsPlayed, nil, "can't finish game, no rounds have been played")
+	}
+	archive.add(newGameRecord(game, time.Now()))
+	game.Guid = NewGuid()
+	game.Started = time.Now()
+	game.FinishedRounds = []*Round{}
+	return nil
+}
+
+// admin mutators -- for getting a wedged game moving again
+
+// forceFinishRound ends the current round, whatever state it's in.  A round that's been
+// played out is recorded as usual; one that's still in progress is abandoned, since its
+// scores would be meaningless.
+func (game *Game) forceFinishRound() error {
+	if game.State != GameStateRoundInProgress {
+		return wrongGameStateError("force finish round", game.State)
+	}
+	if game.CurrentRound.State == RoundStateFinished {
+		return game.finishRound()
+	}
+	game.CurrentRound = nil
+	game.State = GameStateSetup
+	return nil
+}
+
+// forceRemovePlayer removes a player even in the middle of a round.  The round was dealt
+// for them, so it's abandoned.
+func (game *Game) forceRemovePlayer(player string) error {
+	if !game.PlayersSet[player] {
+		return newGameError(ErrorCodeUnknownPlayer, map[string]interface{}{"Player": player}, "can't remove player %s, not present", player)
+	}
+	if game.State == GameStateRoundInProgress {
+		game.CurrentRound = nil
+		game.State = GameStateSetup
+	}
+	return game.removePlayer(player)
+}
+
+// reset starts the game over with no players.  The announcement and version are kept, so
+// that versions keep going up.
+func (game *Game) reset() {
+	fresh := NewGame()
+	fresh.Version = game.Version
+	fresh.Announcement = game.Announcement
+	*game = *fresh
+}
+
+// announce sets the message shown to every player; an empty message clears it
+func (game *Game) announce(message string) {
+	game.Announcement = message
+}
+
 func (game *Game) makeWager(player string, hands int) error {
 	if game.State != GameStateRoundInProgress {
-		return errors.New(fmt.Sprintf("can't make wager, game in state %s", game.State.String()))
+		return wrongGameStateError("make wager", game.State)
 	}
 	return game.CurrentRound.Wager(player, hands)
 }
 
 func (game *Game) playCard(player string, card *Card) error {
 	if game.State != GameStateRoundInProgress {
-		return errors.New(fmt.Sprintf("can't play card, game in state %s", game.State.String()))
+		return wrongGameStateError("play card", game.State)
 	}
 	return game.CurrentRound.PlayCard(player, card)
 }
@@ -15,5 +15,20 @@ func TestModel(t *testing.T) {
 	RunHandTests()
 	RunPlayerStateTests()
 	RunPlayerModelTests()
+	RunArchiveTests()
+	RunPersistenceTests()
+	RunGameConcurrencyWrapperTests()
+	RunMetricsTests()
+	RunHealthTests()
+	RunServerTests()
+	RunErrorTests()
+	RunClientTests()
+	RunRestApiTests()
+	RunAdminApiTests()
+	RunOpenAPITests()
+	RunIdempotencyTests()
+	RunTCPServerTests()
+	RunGRPCServerTests()
+	RunCardNotationTests()
 	RunSpecs(t, "game suite")
 }
@@ -100,15 +100,18 @@ func RunGameTests() {
 				Expect(game.Players).To(Equal([]string{"abc", "ghi"}))
 			})
 
-			emptyPm := &PlayerModel{
-				Me:    "",
-				State: PlayerStateNotJoined,
-				Game: &PlayerGame{
-					Players:           []string{"abc", "def", "ghi"},
-					MaxCardsPerPlayer: 17,
-					CardsPerPlayer:    1,
-					DeckType:          DeckTypeStandard,
-				},
+			emptyPm := func(game *Game) *PlayerModel {
+				return &PlayerModel{
+					Me:    "",
+					State: PlayerStateNotJoined,
+					Game: &PlayerGame{
+						Guid:              game.Guid,
+						Players:           []string{"abc", "def", "ghi"},
+						MaxCardsPerPlayer: 17,
+						CardsPerPlayer:    1,
+						DeckType:          DeckTypeStandard,
+					},
+				}
 			}
 
 			It("should return an 'empty' model for an 'empty' player, no matter whether the game's in progress", func() {
@@ -118,12 +121,12 @@ func RunGameTests() {
 				Expect(joinGame(game, "ghi")).Should(Succeed())
 
 				pm := game.playerModel("")
-				Expect(pm).To(Equal(emptyPm))
+				Expect(pm).To(Equal(emptyPm(game)))
 
 				Expect(game.startRound()).Should(Succeed())
 
 				pm2 := game.playerModel("")
-				Expect(pm2).To(Equal(emptyPm))
+				Expect(pm2).To(Equal(emptyPm(game)))
 			})
 
 			It("should return an 'empty' player model for a nonexisting player", func() {
@@ -133,7 +136,7 @@ func RunGameTests() {
 				Expect(joinGame(game, "ghi")).Should(Succeed())
 
 				pm := game.playerModel("jkl")
-				Expect(pm).To(Equal(emptyPm))
+				Expect(pm).To(Equal(emptyPm(game)))
 			})
 
 			It("should start a round", func() {
@@ -221,5 +224,64 @@ func RunGameTests() {
 				// TODO track moods through more hands, to the end of the round
 			})
 		})
+
+		Describe("Admin", func() {
+			startedGame := func() *Game {
+				game := NewGame()
+				Expect(joinGame(game, "abc")).Should(Succeed())
+				Expect(joinGame(game, "def")).Should(Succeed())
+				Expect(joinGame(game, "ghi")).Should(Succeed())
+				Expect(game.setCardsPerPlayer(1)).Should(Succeed())
+				Expect(game.startRound()).Should(Succeed())
+				return game
+			}
+
+			It("should abandon an unfinished round when force finishing it", func() {
+				game := startedGame()
+				Expect(game.makeWager("abc", 0)).Should(Succeed())
+
+				Expect(game.forceFinishRound()).Should(Succeed())
+				Expect(game.State).To(Equal(GameStateSetup))
+				Expect(game.CurrentRound).To(BeNil())
+				Expect(game.FinishedRounds).To(BeEmpty())
+				Expect(game.Players).To(Equal([]string{"abc", "def", "ghi"}))
+
+				Expect(game.forceFinishRound()).ToNot(Succeed())
+			})
+
+			It("should record a played-out round when force finishing it", func() {
+				game := startedGame()
+				for _, player := range []string{"abc", "def", "ghi"} {
+					Expect(game.makeWager(player, 0)).Should(Succeed())
+				}
+				for _, player := range []string{"abc", "def", "ghi"} {
+					Expect(game.playCard(player, getFirstCard(game.CurrentRound.PlayerCards[player]))).Should(Succeed())
+				}
+
+				Expect(game.forceFinishRound()).Should(Succeed())
+				Expect(game.FinishedRounds).To(HaveLen(1))
+				Expect(game.Players).To(Equal([]string{"def", "ghi", "abc"}))
+			})
+
+			It("should remove a player in the middle of a round", func() {
+				game := startedGame()
+				Expect(game.removePlayer("def")).ToNot(Succeed())
+
+				Expect(game.forceRemovePlayer("def")).Should(Succeed())
+				Expect(game.State).To(Equal(GameStateSetup))
+				Expect(game.Players).To(Equal([]string{"abc", "ghi"}))
+				Expect(game.forceRemovePlayer("def")).ToNot(Succeed())
+			})
+
+			It("should reset the game, keeping its announcement", func() {
+				game := startedGame()
+				game.announce("back in 5")
+
+				game.reset()
+				Expect(game.State).To(Equal(GameStateSetup))
+				Expect(game.Players).To(BeEmpty())
+				Expect(game.playerModel("abc").Announcement).To(Equal("back in 5"))
+			})
+		})
 	})
 }
@@ -1,28 +1,81 @@
 package game
 
 import (
+	"context"
 	"encoding/json"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
+	"runtime/debug"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	// ErrTimeout is returned when an action's deadline passes before it's been applied
+	ErrTimeout = newGameError(ErrorCodeTimeout, nil, "timed out waiting for game action")
+	// ErrCancelled is returned when an action's context is cancelled before it's been applied
+	ErrCancelled = newGameError(ErrorCodeCancelled, nil, "cancelled while waiting for game action")
+	// ErrStopped is returned once the action processor has stopped
+	ErrStopped = newGameError(ErrorCodeUnavailable, nil, "game is no longer processing actions")
 )
 
 type Action struct {
-	Name  string
-	Apply func() error
+	Name    string
+	Context context.Context
+	// Apply changes the game, and returns anything the caller needs to know about the change
+	Apply func() (interface{}, error)
+	// ExpectedVersion, if set, makes the action conditional: it's only applied if the game is
+	// still at this version, and otherwise fails with ErrorCodeVersionConflict
+	ExpectedVersion *int
+	// Queued is when the action was handed to the processor
+	Queued time.Time
+	// Done receives the result of Apply -- including panics, converted into errors
+	Done chan *ActionResult
+}
+
+// ActionResult is what Apply returned.  It's handed back over Done, rather than through
+// variables shared with the caller, since the caller may have stopped waiting for it.
+type ActionResult struct {
+	Value interface{}
+	Err   error
 }
 
+// GameConcurrencyWrapper serializes all changes to a game through a single goroutine.  After
+// each change, it publishes a new snapshot of the game; reads are served from the latest
+// snapshot, so they never wait for -- or hold up -- changes.
 type GameConcurrencyWrapper struct {
-	Game    *Game
+	Game *Game
+	// Archive holds the games finished so far; like Game, it's only touched by the action processor
+	Archive *Archive
 	Stop    <-chan struct{}
 	Actions chan *Action
+	// pings are picked up by the action processor between actions; see Ping
+	pings chan struct{}
+	// Stopped is closed once the action processor has exited; after that, it's safe to access Game directly
+	Stopped  chan struct{}
+	snapshot atomic.Value
+	// changed holds a channel that's closed -- and replaced -- whenever a new snapshot is published
+	changed atomic.Value
+	metrics gameMetrics
 }
 
-func NewGameConcurrencyWrapper(game *Game, stop <-chan struct{}) *GameConcurrencyWrapper {
+func NewGameConcurrencyWrapper(game *Game, archive *Archive, stop <-chan struct{}) *GameConcurrencyWrapper {
 	gcw := &GameConcurrencyWrapper{
 		Game:    game,
+		Archive: archive,
 		Stop:    stop,
 		Actions: make(chan *Action),
+		pings:   make(chan struct{}),
+		Stopped: make(chan struct{}),
+	}
+	snapshot, err := newGameSnapshot(game, archive, nil)
+	if err != nil {
+		// without a snapshot, there's nothing to serve reads from
+		panic(errors.WithMessagef(err, "unable to snapshot game"))
 	}
+	gcw.snapshot.Store(snapshot)
+	gcw.changed.Store(make(chan struct{}))
+	gcw.metrics.observeGame(game)
 	go func() {
 		gcw.startActionProcessor()
 	}()
@@ -30,20 +83,164 @@ func NewGameConcurrencyWrapper(game *Game, stop <-chan struct{}) *GameConcurrenc
 }
 
 func (gcw *GameConcurrencyWrapper) startActionProcessor() {
+	defer close(gcw.Stopped)
+	defer gcw.metrics.retire()
 	for {
 		var action *Action
 		select {
 		case <-gcw.Stop:
-			break
+			log.Infof("stopping action processor")
+			return
+		case <-gcw.pings:
+			continue
 		case action = <-gcw.Actions:
 		}
+		actionQueueSeconds.WithLabelValues(action.Name).Observe(time.Since(action.Queued).Seconds())
+
+		// no point applying an action that the caller has already given up on
+		if err := action.Context.Err(); err != nil {
+			log.Infof("skipping action type %s: %s", action.Name, err)
+			actionsProcessed.WithLabelValues(action.Name, actionOutcomeCancelled).Inc()
+			action.Done <- &ActionResult{Err: contextError(action.Context)}
+			continue
+		}
+
+		// the caller may have decided on this action based on an old version of the game
+		if action.ExpectedVersion != nil && *action.ExpectedVersion != gcw.Game.Version {
+			version := *action.ExpectedVersion
+			log.Infof("rejecting action type %s: expected version %d, found %d", action.Name, version, gcw.Game.Version)
+			actionsProcessed.WithLabelValues(action.Name, actionOutcomeConflict).Inc()
+			action.Done <- &ActionResult{Err: newGameError(ErrorCodeVersionConflict, map[string]interface{}{"Expected": version, "Version": gcw.Game.Version}, "expected game version %d, but game is at version %d", version, gcw.Game.Version)}
+			continue
+		}
 
-		err := action.Apply()
+		before := newRoundMarker(gcw.Game)
+		value, err := gcw.apply(action)
+		observeActionApplied(action.Name, err)
 		if err != nil {
+			// the game hasn't changed -- or has been rolled back -- so the latest snapshot still stands
 			log.Errorf("unable to process action type %s: %s", action.Name, err)
 		} else {
 			log.Infof("successfully processed action type %s", action.Name)
+			gcw.Game.Version++
+			gcw.metrics.observeChange(action.Name, before, gcw.Game, time.Now())
+			gcw.publish()
+		}
+		action.Done <- &ActionResult{Value: value, Err: err}
+	}
+}
+
+// apply runs an action, recovering from any panic so that one bad action can't take down
+// every game.  If an action panics, the game and archive are rolled back to their latest
+// snapshot, since there's no telling how far the action got.
+func (gcw *GameConcurrencyWrapper) apply(action *Action) (value interface{}, err error) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
 		}
+		actionPanics.WithLabelValues(action.Name).Inc()
+		log.Errorf("recovered from panic in action %s: %v\n%s", action.Name, r, debug.Stack())
+		err = newGameError(ErrorCodeInternal, nil, "internal error processing action %s: %v", action.Name, r)
+		gcw.restore(gcw.currentSnapshot())
+	}()
+	return action.Apply()
+}
+
+func (gcw *GameConcurrencyWrapper) restore(checkpoint *gameSnapshot) {
+	game, err := checkpoint.game()
+	if err != nil {
+		// this really shouldn't happen: the checkpoint was serialized from a game a moment ago
+		log.Errorf("unable to restore game from checkpoint, continuing with current state: %+v", err)
+		return
+	}
+	gcw.Game = game
+	// the archive is restored in place, since it's shared with whatever saves it.  Games are
+	// only ever added to the end of the archive, so the snapshot's are the archive as it was.
+	gcw.Archive.Games = checkpoint.Archive.Games
+}
+
+func (gcw *GameConcurrencyWrapper) publish() {
+	snapshot, err := newGameSnapshot(gcw.Game, gcw.Archive, gcw.currentSnapshot())
+	if err != nil {
+		log.Errorf("unable to snapshot game, continuing to serve previous snapshot: %+v", err)
+		return
+	}
+	gcw.snapshot.Store(snapshot)
+	// only the processor publishes, so there's no race between loading and replacing the channel
+	previous := gcw.changed.Load().(chan struct{})
+	gcw.changed.Store(make(chan struct{}))
+	close(previous)
+}
+
+func (gcw *GameConcurrencyWrapper) currentSnapshot() *gameSnapshot {
+	return gcw.snapshot.Load().(*gameSnapshot)
+}
+
+func contextError(ctx context.Context) error {
+	if ctx.Err() == context.DeadlineExceeded {
+		return ErrTimeout
+	}
+	return ErrCancelled
+}
+
+// do sends an action to the processor, and waits for its result.  apply is run on the
+// processor goroutine, and so may safely access the game.
+// If ctx is done before the action is applied, do gives up waiting; note that an action which
+// has already been picked up by the processor may still be applied.
+func (gcw *GameConcurrencyWrapper) do(ctx context.Context, name string, apply func() error) error {
+	_, err := gcw.doForValue(ctx, name, func() (interface{}, error) {
+		return nil, apply()
+	})
+	return err
+}
+
+// doForValue is do, for actions that hand back a value.  Since the caller may give up waiting
+// while the action is still being applied, the value must be returned from apply rather than
+// set in variables captured by apply.
+func (gcw *GameConcurrencyWrapper) doForValue(ctx context.Context, name string, apply func() (interface{}, error)) (interface{}, error) {
+	return gcw.send(newAction(ctx, name, apply))
+}
+
+func newAction(ctx context.Context, name string, apply func() (interface{}, error)) *Action {
+	return &Action{
+		Name:    name,
+		Context: ctx,
+		Apply:   apply,
+		Queued:  time.Now(),
+		Done:    make(chan *ActionResult, 1),
+	}
+}
+
+// send hands action to the processor, and waits for its result -- or for the action's context
+// to be done
+func (gcw *GameConcurrencyWrapper) send(action *Action) (interface{}, error) {
+	ctx := action.Context
+	select {
+	case gcw.Actions <- action:
+	case <-ctx.Done():
+		return nil, contextError(ctx)
+	case <-gcw.Stopped:
+		return nil, ErrStopped
+	}
+	select {
+	case result := <-action.Done:
+		return result.Value, result.Err
+	case <-ctx.Done():
+		return nil, contextError(ctx)
+	}
+}
+
+// Ping waits for the action processor to be ready for another action, without changing the
+// game -- so it fails if the processor has stopped, or is stuck on an action.
+func (gcw *GameConcurrencyWrapper) Ping(ctx context.Context) error {
+	select {
+	case gcw.pings <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return contextError(ctx)
+	case <-gcw.Stopped:
+		return ErrStopped
 	}
 }
 
@@ -53,128 +250,193 @@ func (gcw *GameConcurrencyWrapper) SetDeck() error {
 	return errors.New("TODO")
 }
 
-func (gcw *GameConcurrencyWrapper) SetCardsPerPlayer(count int) error {
-	done := make(chan error)
-	gcw.Actions <- &Action{"setCardsPerPlayer", func() error {
-		err := gcw.Game.setCardsPerPlayer(count)
-		go func() {
-			done <- err
-		}()
-		return err
-	}}
-	return <-done
-}
-
-func (gcw *GameConcurrencyWrapper) SetDeckType(deckType DeckType) error {
-	done := make(chan error)
-	gcw.Actions <- &Action{"setDeckType", func() error {
-		err := gcw.Game.setDeckType(deckType)
-		go func() {
-			done <- err
-		}()
-		return err
-	}}
-	return <-done
-}
-
-func (gcw *GameConcurrencyWrapper) Join(player string) (string, error) {
-	done := make(chan struct{})
-	var err error
-	var addedPlayer string
-	gcw.Actions <- &Action{"join", func() error {
-		addedPlayer, err = gcw.Game.join(player)
-		close(done)
-		return err
-	}}
-	<-done
-	return addedPlayer, err
-}
-
-func (gcw *GameConcurrencyWrapper) RemovePlayer(player string) error {
-	done := make(chan error)
-	gcw.Actions <- &Action{"removePlayer", func() error {
-		err := gcw.Game.removePlayer(player)
-		go func() {
-			done <- err
-		}()
-		return err
-	}}
-	return <-done
-}
-
-func (gcw *GameConcurrencyWrapper) StartRound() error {
-	done := make(chan error)
-	gcw.Actions <- &Action{"startRound", func() error {
-		err := gcw.Game.startRound()
-		go func() {
-			done <- err
-		}()
-		return err
-	}}
-	return <-done
-}
-
-func (gcw *GameConcurrencyWrapper) FinishRound() error {
-	done := make(chan error)
-	gcw.Actions <- &Action{"finishRound", func() error {
-		err := gcw.Game.finishRound()
-		go func() {
-			done <- err
-		}()
-		return err
-	}}
-	return <-done
-}
-
-func (gcw *GameConcurrencyWrapper) MakeWager(player string, hands int) error {
-	done := make(chan error)
-	gcw.Actions <- &Action{"makeWager", func() error {
-		err := gcw.Game.makeWager(player, hands)
-		go func() {
-			done <- err
-		}()
-		return err
-	}}
-	return <-done
-}
-
-func (gcw *GameConcurrencyWrapper) PlayCard(player string, card *Card) error {
-	done := make(chan error)
-	gcw.Actions <- &Action{"playCard", func() error {
-		err := gcw.Game.playCard(player, card)
-		go func() {
-			done <- err
-		}()
-		return err
-	}}
-	return <-done
+// Act applies a player's action, and returns the player whose model should be sent back --
+// which, for a join, is the name the player actually got.  If the action has an
+// ExpectedVersion, it's only applied if the game is still at that version.
+func (gcw *GameConcurrencyWrapper) Act(ctx context.Context, playerAction *PlayerAction) (string, error) {
+	name, apply, err := gcw.playerAction(playerAction)
+	if err != nil {
+		return "", err
+	}
+	action := newAction(ctx, name, apply)
+	action.ExpectedVersion = playerAction.ExpectedVersion
+	player, err := gcw.send(action)
+	if err != nil {
+		return "", err
+	}
+	return player.(string), nil
 }
 
-// getters
-
-func (gcw *GameConcurrencyWrapper) GetModel() string {
-	done := make(chan string)
-	gcw.Actions <- &Action{"getJsonModel", func() error {
-		bytes, err := json.MarshalIndent(gcw.Game, "", "  ")
-		if err != nil {
-			panic(err)
+// playerAction finds the change that a player's action makes to the game.  Applying it hands
+// back the acting player.
+func (gcw *GameConcurrencyWrapper) playerAction(action *PlayerAction) (string, func() (interface{}, error), error) {
+	me := action.Me
+	apply := func(change func() error) func() (interface{}, error) {
+		return func() (interface{}, error) {
+			return me, change()
 		}
-		go func() {
-			done <- string(bytes)
-		}()
+	}
+	switch {
+	case action.Join != nil:
+		return "join", func() (interface{}, error) {
+			return gcw.Game.join(me)
+		}, nil
+	case action.RemovePlayer != nil:
+		player := action.RemovePlayer.Player
+		return "removePlayer", apply(func() error {
+			return gcw.Game.removePlayer(player)
+		}), nil
+	case action.SetCardsPerPlayer != nil:
+		count := action.SetCardsPerPlayer.Count
+		return "setCardsPerPlayer", apply(func() error {
+			return gcw.Game.setCardsPerPlayer(count)
+		}), nil
+	case action.SetDeckType != nil:
+		deckType := action.SetDeckType.DeckType
+		return "setDeckType", apply(func() error {
+			return gcw.Game.setDeckType(deckType)
+		}), nil
+	case action.StartRound != nil:
+		return "startRound", apply(gcw.startRound), nil
+	case action.MakeWager != nil:
+		hands := action.MakeWager.Hands
+		return "makeWager", apply(func() error {
+			return gcw.Game.makeWager(me, hands)
+		}), nil
+	case action.PlayCard != nil:
+		card := &Card{Suit: action.PlayCard.Suit, Number: action.PlayCard.Number}
+		return "playCard", apply(func() error {
+			return gcw.Game.playCard(me, card)
+		}), nil
+	case action.FinishRound != nil:
+		return "finishRound", apply(gcw.finishRound), nil
+	case action.FinishGame != nil:
+		return "finishGame", apply(gcw.finishGame), nil
+	}
+	return "", nil, newGameError(ErrorCodeInvalidRequest, nil, "action must have non-nil for one of GetModel, Join, RemovePlayer, SetCardsPerPlayer, SetDeckType, StartRound, MakeWager, PlayCard, FinishRound, or FinishGame")
+}
+
+func (gcw *GameConcurrencyWrapper) startRound() error {
+	return gcw.Game.startRound()
+}
+
+func (gcw *GameConcurrencyWrapper) finishRound() error {
+	return gcw.Game.finishRound()
+}
+
+func (gcw *GameConcurrencyWrapper) finishGame() error {
+	return gcw.Game.finishGame(gcw.Archive)
+}
+
+func (gcw *GameConcurrencyWrapper) SetCardsPerPlayer(ctx context.Context, count int) error {
+	_, err := gcw.Act(ctx, &PlayerAction{SetCardsPerPlayer: &SetCardsPerPlayerAction{Count: count}})
+	return err
+}
+
+func (gcw *GameConcurrencyWrapper) SetDeckType(ctx context.Context, deckType DeckType) error {
+	_, err := gcw.Act(ctx, &PlayerAction{SetDeckType: &SetDeckTypePlayerAction{DeckType: deckType}})
+	return err
+}
+
+func (gcw *GameConcurrencyWrapper) Join(ctx context.Context, player string) (string, error) {
+	return gcw.Act(ctx, &PlayerAction{Me: player, Join: &JoinAction{}})
+}
+
+func (gcw *GameConcurrencyWrapper) RemovePlayer(ctx context.Context, player string) error {
+	_, err := gcw.Act(ctx, &PlayerAction{RemovePlayer: &RemovePlayerAction{Player: player}})
+	return err
+}
+
+func (gcw *GameConcurrencyWrapper) StartRound(ctx context.Context) error {
+	_, err := gcw.Act(ctx, &PlayerAction{StartRound: &StartRoundAction{}})
+	return err
+}
+
+func (gcw *GameConcurrencyWrapper) FinishRound(ctx context.Context) error {
+	_, err := gcw.Act(ctx, &PlayerAction{FinishRound: &FinishRoundAction{}})
+	return err
+}
+
+func (gcw *GameConcurrencyWrapper) MakeWager(ctx context.Context, player string, hands int) error {
+	_, err := gcw.Act(ctx, &PlayerAction{Me: player, MakeWager: &MakeWagerAction{Hands: hands}})
+	return err
+}
+
+func (gcw *GameConcurrencyWrapper) PlayCard(ctx context.Context, player string, card *Card) error {
+	_, err := gcw.Act(ctx, &PlayerAction{Me: player, PlayCard: card})
+	return err
+}
+
+func (gcw *GameConcurrencyWrapper) FinishGame(ctx context.Context) error {
+	_, err := gcw.Act(ctx, &PlayerAction{FinishGame: &FinishGameAction{}})
+	return err
+}
+
+// admin mutators
+
+func (gcw *GameConcurrencyWrapper) ForceFinishRound(ctx context.Context) error {
+	return gcw.do(ctx, "forceFinishRound", func() error {
+		return gcw.Game.forceFinishRound()
+	})
+}
+
+func (gcw *GameConcurrencyWrapper) ForceRemovePlayer(ctx context.Context, player string) error {
+	return gcw.do(ctx, "forceRemovePlayer", func() error {
+		return gcw.Game.forceRemovePlayer(player)
+	})
+}
+
+func (gcw *GameConcurrencyWrapper) Reset(ctx context.Context) error {
+	return gcw.do(ctx, "reset", func() error {
+		gcw.Game.reset()
 		return nil
-	}}
-	return <-done
+	})
 }
 
-func (gcw *GameConcurrencyWrapper) GetPlayerModel(player string) *PlayerModel {
-	done := make(chan struct{})
-	var pm *PlayerModel
-	gcw.Actions <- &Action{"getJsonModel", func() error {
-		pm = gcw.Game.playerModel(player)
-		close(done)
+func (gcw *GameConcurrencyWrapper) Announce(ctx context.Context, message string) error {
+	return gcw.do(ctx, "announce", func() error {
+		gcw.Game.announce(message)
 		return nil
-	}}
-	<-done
-	return pm
+	})
+}
+
+// getters
+
+func (gcw *GameConcurrencyWrapper) GetModel() (string, error) {
+	game, err := gcw.currentSnapshot().game()
+	if err != nil {
+		return "", err
+	}
+	bytes, err := json.MarshalIndent(game, "", "  ")
+	if err != nil {
+		return "", errors.Wrapf(err, "unable to format game model")
+	}
+	return string(bytes), nil
+}
+
+// Changed returns a channel that's closed the next time the game might have changed.  To avoid
+// missing a change, get the channel before reading the game.
+func (gcw *GameConcurrencyWrapper) Changed() <-chan struct{} {
+	return gcw.changed.Load().(chan struct{})
+}
+
+// GetPublicModel is the game as seen by someone who isn't playing: no player's cards are included
+func (gcw *GameConcurrencyWrapper) GetPublicModel() (*PublicModel, error) {
+	return gcw.currentSnapshot().Public, nil
+}
+
+func (gcw *GameConcurrencyWrapper) GetPlayerModel(player string) (*PlayerModel, error) {
+	return gcw.currentSnapshot().playerModel(player), nil
+}
+
+func (gcw *GameConcurrencyWrapper) GetFinishedRounds() ([]*RoundRecord, error) {
+	return gcw.currentSnapshot().FinishedRounds, nil
+}
+
+func (gcw *GameConcurrencyWrapper) GetArchive(offset int, limit int) (*ArchivePage, error) {
+	return gcw.currentSnapshot().Archive.page(offset, limit)
+}
+
+func (gcw *GameConcurrencyWrapper) GetArchivedGame(guid string) (*GameRecord, error) {
+	return gcw.currentSnapshot().Archive.game(guid)
 }
@@ -0,0 +1,216 @@
+package game
+
+import (
+	"context"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"time"
+)
+
+func RunGameConcurrencyWrapperTests() {
+	Describe("GameConcurrencyWrapper", func() {
+		ctx := context.Background()
+
+		It("should process actions until stopped", func() {
+			stop := make(chan struct{})
+			gcw := NewGameConcurrencyWrapper(NewGame(), NewArchive(), stop)
+
+			Expect(gcw.Join(ctx, "abc")).To(Equal("abc"))
+			pm, err := gcw.GetPlayerModel("abc")
+			Expect(err).Should(Succeed())
+			Expect(pm.State).To(Equal(PlayerStateWaitingForPlayers))
+
+			close(stop)
+			Eventually(gcw.Stopped).Should(BeClosed())
+			Expect(gcw.Game.Players).To(Equal([]string{"abc"}))
+		})
+
+		It("should recover from a panicking action, and roll the game back", func() {
+			stop := make(chan struct{})
+			defer close(stop)
+			gcw := NewGameConcurrencyWrapper(NewGame(), NewArchive(), stop)
+			Expect(gcw.Join(ctx, "abc")).To(Equal("abc"))
+
+			err := gcw.do(ctx, "explode", func() error {
+				gcw.Game.Players = append(gcw.Game.Players, "zzz")
+				gcw.Game.Deck.CompareNumbers("abc", "def")
+				return nil
+			})
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).To(ContainSubstring("internal error processing action explode"))
+
+			// still processing actions, from the last consistent state
+			Expect(gcw.Join(ctx, "def")).To(Equal("def"))
+			pm, err := gcw.GetPlayerModel("def")
+			Expect(err).Should(Succeed())
+			Expect(pm.Game.Players).To(Equal([]string{"abc", "def"}))
+		})
+
+		It("should roll the archive back along with the game", func() {
+			stop := make(chan struct{})
+			defer close(stop)
+			gcw := NewGameConcurrencyWrapper(NewGame(), NewArchive(), stop)
+			gcw.Game.Deck = NewDeterministicShuffleDeck()
+			for _, player := range []string{"abc", "def"} {
+				_, err := gcw.Join(ctx, player)
+				Expect(err).Should(Succeed())
+			}
+			Expect(gcw.StartRound(ctx)).Should(Succeed())
+			Expect(gcw.MakeWager(ctx, "abc", 0)).Should(Succeed())
+			Expect(gcw.MakeWager(ctx, "def", 0)).Should(Succeed())
+			Expect(gcw.PlayCard(ctx, "abc", &Card{Suit: "Clubs", Number: "2"})).Should(Succeed())
+			Expect(gcw.PlayCard(ctx, "def", &Card{Suit: "Clubs", Number: "3"})).Should(Succeed())
+			Expect(gcw.FinishRound(ctx)).Should(Succeed())
+			guid := gcw.Game.Guid
+
+			// finishing the game archives it, then starts a new one -- blow up after both
+			err := gcw.do(ctx, "explode", func() error {
+				if err := gcw.Game.finishGame(gcw.Archive); err != nil {
+					return err
+				}
+				gcw.Game.Deck.CompareNumbers("abc", "def")
+				return nil
+			})
+			Expect(err.Error()).To(ContainSubstring("internal error processing action explode"))
+
+			Expect(gcw.Game.Guid).To(Equal(guid))
+			Expect(gcw.Game.FinishedRounds).To(HaveLen(1))
+			Expect(gcw.Archive.Games).To(BeEmpty())
+
+			// and finishing it for real archives it just once
+			Expect(gcw.FinishGame(ctx)).Should(Succeed())
+			page, err := gcw.GetArchive(0, 10)
+			Expect(err).Should(Succeed())
+			Expect(page.Games).To(HaveLen(1))
+			Expect(page.Games[0].Guid).To(Equal(guid))
+		})
+
+		It("should only publish a new snapshot when the game changes", func() {
+			stop := make(chan struct{})
+			defer close(stop)
+			gcw := NewGameConcurrencyWrapper(NewGame(), NewArchive(), stop)
+			gcw.Game.Deck = NewDeterministicShuffleDeck()
+			for _, player := range []string{"abc", "def"} {
+				_, err := gcw.Join(ctx, player)
+				Expect(err).Should(Succeed())
+			}
+
+			changed := gcw.Changed()
+			snapshot := gcw.currentSnapshot()
+			Expect(gcw.MakeWager(ctx, "abc", 0)).ShouldNot(Succeed())
+			Expect(changed).ToNot(BeClosed())
+			Expect(gcw.currentSnapshot()).To(BeIdenticalTo(snapshot))
+
+			Expect(gcw.StartRound(ctx)).Should(Succeed())
+			Expect(changed).To(BeClosed())
+			Expect(gcw.MakeWager(ctx, "abc", 0)).Should(Succeed())
+			Expect(gcw.MakeWager(ctx, "def", 0)).Should(Succeed())
+			Expect(gcw.PlayCard(ctx, "abc", &Card{Suit: "Clubs", Number: "2"})).Should(Succeed())
+			Expect(gcw.PlayCard(ctx, "def", &Card{Suit: "Clubs", Number: "3"})).Should(Succeed())
+			Expect(gcw.FinishRound(ctx)).Should(Succeed())
+			rounds, err := gcw.GetFinishedRounds()
+			Expect(err).Should(Succeed())
+			Expect(rounds).To(HaveLen(1))
+
+			// the finished rounds are carried over to later snapshots, and still in the full dump
+			Expect(gcw.SetCardsPerPlayer(ctx, 2)).Should(Succeed())
+			later, err := gcw.GetFinishedRounds()
+			Expect(err).Should(Succeed())
+			Expect(later[0]).To(BeIdenticalTo(rounds[0]))
+			model, err := gcw.GetModel()
+			Expect(err).Should(Succeed())
+			Expect(model).To(ContainSubstring(rounds[0].Guid))
+		})
+
+		It("should serve reads while an action is in progress", func() {
+			stop := make(chan struct{})
+			defer close(stop)
+			gcw := NewGameConcurrencyWrapper(NewGame(), NewArchive(), stop)
+			Expect(gcw.Join(ctx, "abc")).To(Equal("abc"))
+
+			started, release := make(chan struct{}), make(chan struct{})
+			go func() {
+				defer GinkgoRecover()
+				Expect(gcw.do(ctx, "slowJoin", func() error {
+					close(started)
+					<-release
+					_, err := gcw.Game.join("def")
+					return err
+				})).Should(Succeed())
+			}()
+			<-started
+
+			pm, err := gcw.GetPlayerModel("abc")
+			Expect(err).Should(Succeed())
+			Expect(pm.Game.Players).To(Equal([]string{"abc"}))
+			model, err := gcw.GetModel()
+			Expect(err).Should(Succeed())
+			Expect(model).To(ContainSubstring(`"abc"`))
+
+			close(release)
+			Eventually(func() []string {
+				pm, _ := gcw.GetPlayerModel("abc")
+				return pm.Game.Players
+			}).Should(Equal([]string{"abc", "def"}))
+		})
+
+		It("should time out waiting on a stuck processor", func() {
+			stop := make(chan struct{})
+			defer close(stop)
+			gcw := NewGameConcurrencyWrapper(NewGame(), NewArchive(), stop)
+
+			started, release := make(chan struct{}), make(chan struct{})
+			defer close(release)
+			go func() {
+				gcw.do(ctx, "stuck", func() error {
+					close(started)
+					<-release
+					return nil
+				})
+			}()
+			<-started
+
+			timeoutCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+			defer cancel()
+			_, err := gcw.Join(timeoutCtx, "abc")
+			Expect(err).To(Equal(ErrTimeout))
+
+			cancelledCtx, cancel := context.WithCancel(ctx)
+			cancel()
+			Expect(gcw.StartRound(cancelledCtx)).To(Equal(ErrCancelled))
+		})
+
+		It("should not apply actions once stopped", func() {
+			stop := make(chan struct{})
+			gcw := NewGameConcurrencyWrapper(NewGame(), NewArchive(), stop)
+			close(stop)
+			Eventually(gcw.Stopped).Should(BeClosed())
+
+			_, err := gcw.Join(ctx, "abc")
+			Expect(err).To(Equal(ErrStopped))
+			Expect(gcw.Game.Players).To(BeEmpty())
+		})
+
+		It("should only apply actions at the expected version", func() {
+			stop := make(chan struct{})
+			defer close(stop)
+			gcw := NewGameConcurrencyWrapper(NewGame(), NewArchive(), stop)
+			Expect(gcw.Join(ctx, "abc")).To(Equal("abc"))
+			pm, err := gcw.GetPlayerModel("abc")
+			Expect(err).Should(Succeed())
+			Expect(pm.Version).To(Equal(1))
+
+			// failed actions don't change the version
+			Expect(gcw.StartRound(ctx)).ShouldNot(Succeed())
+			version := 1
+			Expect(gcw.Act(ctx, &PlayerAction{Me: "def", Join: &JoinAction{}, ExpectedVersion: &version})).To(Equal("def"))
+
+			_, err = gcw.Act(ctx, &PlayerAction{Me: "abc", RemovePlayer: &RemovePlayerAction{Player: "def"}, ExpectedVersion: &version})
+			Expect(AsGameError(err).Code).To(Equal(ErrorCodeVersionConflict))
+			Expect(AsGameError(err).Details["Version"]).To(Equal(2))
+			pm, err = gcw.GetPlayerModel("abc")
+			Expect(err).Should(Succeed())
+			Expect(pm.Game.Players).To(Equal([]string{"abc", "def"}))
+		})
+	})
+}
@@ -0,0 +1,321 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/mattfenwick/upanddowntheriver/pkg/gamepb"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net"
+	"sync"
+)
+
+// GRPCServer serves the same game as the HTTP API, over gRPC.  Like POSTs to /action, every
+// RPC returns the acting player's model.
+type GRPCServer struct {
+	Responder Responder
+	server    *grpc.Server
+	// idempotentResults remembers actions sent with idempotency keys, so that retries are safe
+	idempotentResults *idempotencyCache
+	// stopping is closed on Shutdown, so that watch streams -- which would otherwise run
+	// forever -- end, and let a graceful stop finish
+	stopping     chan struct{}
+	stoppingOnce sync.Once
+}
+
+func NewGRPCServer(responder Responder) *GRPCServer {
+	gs := &GRPCServer{
+		Responder:         responder,
+		server:            grpc.NewServer(),
+		idempotentResults: newIdempotencyCache(idempotencyKeysPerPlayer, idempotencyPlayers),
+		stopping:          make(chan struct{}),
+	}
+	gamepb.RegisterUpAndDownTheRiverServer(gs.server, gs)
+	return gs
+}
+
+// Serve accepts connections on listener until Shutdown is called
+func (gs *GRPCServer) Serve(listener net.Listener) error {
+	return gs.server.Serve(listener)
+}
+
+// Shutdown stops accepting connections, ends watch streams, and waits for in-flight RPCs to
+// finish; if ctx is done first, the remaining RPCs are cut off
+func (gs *GRPCServer) Shutdown(ctx context.Context) error {
+	gs.stoppingOnce.Do(func() {
+		close(gs.stopping)
+	})
+	done := make(chan struct{})
+	go func() {
+		gs.server.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		gs.server.Stop()
+		return ctx.Err()
+	}
+}
+
+// grpcResult is the outcome of an action, as remembered for its idempotency key
+type grpcResult struct {
+	model *gamepb.PlayerModel
+	err   error
+}
+
+// act runs an action, at most once per idempotency key, and responds with the acting player's
+// model
+func (gs *GRPCServer) act(ctx context.Context, me string, options *gamepb.ActionOptions, action *PlayerAction) (*gamepb.PlayerModel, error) {
+	action.Me = me
+	if version := options.GetExpectedVersion(); version != nil {
+		expected := int(version.GetValue())
+		action.ExpectedVersion = &expected
+	}
+	key := options.GetIdempotencyKey()
+	if key == "" {
+		model, err := gs.apply(ctx, action)
+		if err != nil {
+			return nil, grpcError(err)
+		}
+		return model, nil
+	}
+	value, err := gs.idempotentResults.once(ctx, me, key, func() (interface{}, bool) {
+		// a retry will be waiting for this result, so see the action through even if this
+		// call is abandoned
+		model, err := gs.apply(context.Background(), action)
+		return &grpcResult{model: model, err: err}, err == nil || isDefinitiveStatus(AsGameError(err).Code.HTTPStatus())
+	})
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	result, ok := value.(*grpcResult)
+	if !ok {
+		// the first call never finished
+		return nil, grpcError(newGameError(ErrorCodeInternal, nil, "unable to handle action"))
+	}
+	if result.err != nil {
+		return nil, grpcError(result.err)
+	}
+	return result.model, nil
+}
+
+// apply runs an action with the same timeout as the HTTP API, and gets the acting player's model
+func (gs *GRPCServer) apply(ctx context.Context, action *PlayerAction) (*gamepb.PlayerModel, error) {
+	ctx, cancel := context.WithTimeout(ctx, actionTimeout)
+	defer cancel()
+	if _, err := gs.Responder.Act(ctx, action); err != nil {
+		return nil, err
+	}
+	pm, err := gs.Responder.GetPlayerModel(action.Me)
+	if err != nil {
+		return nil, err
+	}
+	return playerModelToProto(pm), nil
+}
+
+func (gs *GRPCServer) playerModel(me string) (*gamepb.PlayerModel, error) {
+	pm, err := gs.Responder.GetPlayerModel(me)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return playerModelToProto(pm), nil
+}
+
+func (gs *GRPCServer) Join(ctx context.Context, req *gamepb.JoinRequest) (*gamepb.PlayerModel, error) {
+	ctx, cancel := context.WithTimeout(ctx, actionTimeout)
+	defer cancel()
+	player, err := gs.Responder.Join(ctx, req.GetMe())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return gs.playerModel(player)
+}
+
+func (gs *GRPCServer) RemovePlayer(ctx context.Context, req *gamepb.RemovePlayerRequest) (*gamepb.PlayerModel, error) {
+	return gs.act(ctx, req.GetMe(), req.GetOptions(), &PlayerAction{RemovePlayer: &RemovePlayerAction{Player: req.GetPlayer()}})
+}
+
+func (gs *GRPCServer) SetCardsPerPlayer(ctx context.Context, req *gamepb.SetCardsPerPlayerRequest) (*gamepb.PlayerModel, error) {
+	return gs.act(ctx, req.GetMe(), req.GetOptions(), &PlayerAction{SetCardsPerPlayer: &SetCardsPerPlayerAction{Count: int(req.GetCount())}})
+}
+
+func (gs *GRPCServer) SetDeckType(ctx context.Context, req *gamepb.SetDeckTypeRequest) (*gamepb.PlayerModel, error) {
+	var deckType DeckType
+	if err := deckType.UnmarshalText([]byte(req.GetDeckType())); err != nil {
+		return nil, grpcError(newGameError(ErrorCodeInvalidDeck, nil, "%s", err.Error()))
+	}
+	return gs.act(ctx, req.GetMe(), req.GetOptions(), &PlayerAction{SetDeckType: &SetDeckTypePlayerAction{DeckType: deckType}})
+}
+
+func (gs *GRPCServer) StartRound(ctx context.Context, req *gamepb.PlayerRequest) (*gamepb.PlayerModel, error) {
+	return gs.act(ctx, req.GetMe(), req.GetOptions(), &PlayerAction{StartRound: &StartRoundAction{}})
+}
+
+func (gs *GRPCServer) MakeWager(ctx context.Context, req *gamepb.MakeWagerRequest) (*gamepb.PlayerModel, error) {
+	return gs.act(ctx, req.GetMe(), req.GetOptions(), &PlayerAction{MakeWager: &MakeWagerAction{Hands: int(req.GetHands())}})
+}
+
+func (gs *GRPCServer) PlayCard(ctx context.Context, req *gamepb.PlayCardRequest) (*gamepb.PlayerModel, error) {
+	if req.GetCard() == nil {
+		return nil, grpcError(newGameError(ErrorCodeInvalidCard, nil, "missing card"))
+	}
+	return gs.act(ctx, req.GetMe(), req.GetOptions(), &PlayerAction{PlayCard: cardFromProto(req.GetCard())})
+}
+
+func (gs *GRPCServer) FinishRound(ctx context.Context, req *gamepb.PlayerRequest) (*gamepb.PlayerModel, error) {
+	return gs.act(ctx, req.GetMe(), req.GetOptions(), &PlayerAction{FinishRound: &FinishRoundAction{}})
+}
+
+func (gs *GRPCServer) FinishGame(ctx context.Context, req *gamepb.PlayerRequest) (*gamepb.PlayerModel, error) {
+	return gs.act(ctx, req.GetMe(), req.GetOptions(), &PlayerAction{FinishGame: &FinishGameAction{}})
+}
+
+func (gs *GRPCServer) GetPlayerModel(ctx context.Context, req *gamepb.PlayerRequest) (*gamepb.PlayerModel, error) {
+	return gs.playerModel(req.GetMe())
+}
+
+// WatchPlayerModel sends the current model right away, and then each new version of it, until
+// the client goes away or the server shuts down
+func (gs *GRPCServer) WatchPlayerModel(req *gamepb.PlayerRequest, stream gamepb.UpAndDownTheRiver_WatchPlayerModelServer) error {
+	lastVersion := -1
+	for {
+		// get the channel first, so that a change between reading the model and waiting isn't missed
+		changed := gs.Responder.Changed()
+		pm, err := gs.Responder.GetPlayerModel(req.GetMe())
+		if err != nil {
+			return grpcError(err)
+		}
+		if pm.Version != lastVersion {
+			if err := stream.Send(playerModelToProto(pm)); err != nil {
+				log.Infof("unable to send player model to watcher %s: %s", req.GetMe(), err)
+				return err
+			}
+			lastVersion = pm.Version
+		}
+		select {
+		case <-changed:
+		case <-stream.Context().Done():
+			return nil
+		case <-gs.stopping:
+			return status.Error(codes.Unavailable, "server is shutting down")
+		}
+	}
+}
+
+// grpcError converts err into a status whose code is the closest match to its GameError code,
+// and whose message starts with the GameError code, so clients can still tell errors apart
+func grpcError(err error) error {
+	gameError := AsGameError(err)
+	return status.Error(gameError.Code.GRPCCode(), fmt.Sprintf("%s: %s", gameError.Code, gameError.Message))
+}
+
+// conversions
+
+func cardToProto(card *Card) *gamepb.Card {
+	if card == nil {
+		return nil
+	}
+	return &gamepb.Card{Suit: card.Suit, Number: card.Number}
+}
+
+func cardsToProto(cards []*Card) []*gamepb.Card {
+	out := []*gamepb.Card{}
+	for _, card := range cards {
+		out = append(out, cardToProto(card))
+	}
+	return out
+}
+
+func cardFromProto(card *gamepb.Card) *Card {
+	return &Card{Suit: card.GetSuit(), Number: card.GetNumber()}
+}
+
+func cardPlaysToProto(plays []*CardPlay) []*gamepb.CardPlay {
+	out := []*gamepb.CardPlay{}
+	for _, play := range plays {
+		out = append(out, &gamepb.CardPlay{Player: play.Player, Card: cardToProto(play.Card)})
+	}
+	return out
+}
+
+func optionalIntToProto(i *int) *wrappers.Int32Value {
+	if i == nil {
+		return nil
+	}
+	return &wrappers.Int32Value{Value: int32(*i)}
+}
+
+func playerModelToProto(pm *PlayerModel) *gamepb.PlayerModel {
+	out := &gamepb.PlayerModel{
+		Version: int32(pm.Version),
+		Me:      pm.Me,
+		State:   pm.State.JSONString(),
+		Game: &gamepb.PlayerGame{
+			Guid:              pm.Game.Guid,
+			Players:           pm.Game.Players,
+			MaxCardsPerPlayer: int32(pm.Game.MaxCardsPerPlayer),
+			CardsPerPlayer:    int32(pm.Game.CardsPerPlayer),
+			DeckType:          pm.Game.DeckType.JSONString(),
+		},
+		MyCards:      cardsToProto(pm.MyCards),
+		LegalCards:   cardsToProto(pm.LegalCards),
+		Announcement: pm.Announcement,
+	}
+	for _, wager := range pm.LegalWagers {
+		out.LegalWagers = append(out.LegalWagers, int32(wager))
+	}
+	if pm.Status == nil {
+		return out
+	}
+
+	status := &gamepb.Status{
+		TrumpSuit:       pm.Status.TrumpSuit,
+		NextWagerPlayer: pm.Status.NextWagerPlayer,
+		WagerSum:        int32(pm.Status.WagerSum),
+	}
+	for _, ps := range pm.Status.PlayerStatuses {
+		status.PlayerStatuses = append(status.PlayerStatuses, &gamepb.PlayerStatus{
+			Player:           ps.Player,
+			IsMe:             ps.IsMe,
+			IsNextWagerer:    ps.IsNextWagerer,
+			IsNextPlayer:     ps.IsNextPlayer,
+			IsCurrentLeader:  ps.IsCurrentLeader,
+			IsPreviousWinner: ps.IsPreviousWinner,
+			Mood:             ps.Mood.JSONString(),
+			Wager:            optionalIntToProto(ps.Wager),
+			HandsWon:         optionalIntToProto(ps.HandsWon),
+			PreviousCard:     cardToProto(ps.PreviousCard),
+			CurrentCard:      cardToProto(ps.CurrentCard),
+		})
+	}
+	if hand := pm.Status.PreviousHand; hand != nil {
+		status.PreviousHand = &gamepb.PreviousHand{
+			Suit:   hand.Suit,
+			Winner: hand.Winner,
+		}
+	}
+	if hand := pm.Status.CurrentHand; hand != nil {
+		status.CurrentHand = &gamepb.CurrentHand{
+			Suit:       hand.Suit,
+			Leader:     hand.Leader,
+			LeaderCard: cardToProto(hand.LeaderCard),
+			NextPlayer: hand.NextPlayer,
+			Plays:      cardPlaysToProto(hand.Plays),
+		}
+	}
+	for _, trick := range pm.Status.Tricks {
+		status.Tricks = append(status.Tricks, &gamepb.Trick{
+			Suit:     trick.Suit,
+			Cards:    cardPlaysToProto(trick.Cards),
+			Winner:   trick.Winner,
+			Notation: trick.Notation,
+		})
+	}
+	out.Status = status
+	return out
+}
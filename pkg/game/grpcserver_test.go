@@ -0,0 +1,121 @@
+package game
+
+import (
+	"context"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/mattfenwick/upanddowntheriver/pkg/gamepb"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net"
+)
+
+func RunGRPCServerTests() {
+	Describe("GRPCServer", func() {
+		var gcw *GameConcurrencyWrapper
+		var server *GRPCServer
+		var client gamepb.UpAndDownTheRiverClient
+		var stop chan struct{}
+		var conn *grpc.ClientConn
+
+		BeforeEach(func() {
+			stop = make(chan struct{})
+			gcw = NewGameConcurrencyWrapper(NewGame(), NewArchive(), stop)
+			server = NewGRPCServer(gcw)
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).Should(Succeed())
+			go server.Serve(listener)
+			conn, err = grpc.Dial(listener.Addr().String(), grpc.WithInsecure())
+			Expect(err).Should(Succeed())
+			client = gamepb.NewUpAndDownTheRiverClient(conn)
+		})
+
+		AfterEach(func() {
+			conn.Close()
+			server.Shutdown(context.Background())
+			close(stop)
+		})
+
+		It("should play a round, and report errors with their game error codes", func() {
+			ctx := context.Background()
+			pm, err := client.Join(ctx, &gamepb.JoinRequest{Me: "abc"})
+			Expect(err).Should(Succeed())
+			Expect(pm.State).To(Equal("WaitingForPlayers"))
+			_, err = client.Join(ctx, &gamepb.JoinRequest{Me: "def"})
+			Expect(err).Should(Succeed())
+
+			_, err = client.SetDeckType(ctx, &gamepb.SetDeckTypeRequest{Me: "abc", DeckType: "Tarot"})
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+			_, err = client.SetCardsPerPlayer(ctx, &gamepb.SetCardsPerPlayerRequest{Me: "abc", Count: 1})
+			Expect(err).Should(Succeed())
+			pm, err = client.StartRound(ctx, &gamepb.PlayerRequest{Me: "abc"})
+			Expect(err).Should(Succeed())
+			Expect(pm.LegalWagers).To(Equal([]int32{0, 1}))
+
+			_, err = client.MakeWager(ctx, &gamepb.MakeWagerRequest{Me: "def", Hands: 0})
+			Expect(status.Convert(err).Message()).To(HavePrefix("NotYourTurn: "))
+			stale := &gamepb.ActionOptions{ExpectedVersion: &wrappers.Int32Value{Value: pm.Version - 1}}
+			_, err = client.MakeWager(ctx, &gamepb.MakeWagerRequest{Me: "abc", Hands: 0, Options: stale})
+			Expect(status.Code(err)).To(Equal(codes.Aborted))
+			// a retried action, with the same idempotency key, is only applied once
+			once := &gamepb.ActionOptions{IdempotencyKey: "wager-abc"}
+			first, err := client.MakeWager(ctx, &gamepb.MakeWagerRequest{Me: "abc", Hands: 0, Options: once})
+			Expect(err).Should(Succeed())
+			retried, err := client.MakeWager(ctx, &gamepb.MakeWagerRequest{Me: "abc", Hands: 0, Options: once})
+			Expect(err).Should(Succeed())
+			Expect(retried.Version).To(Equal(first.Version))
+			pm, err = client.MakeWager(ctx, &gamepb.MakeWagerRequest{Me: "def", Hands: 0})
+			Expect(err).Should(Succeed())
+			Expect(pm.Status.PlayerStatuses[1].Wager.GetValue()).To(Equal(int32(0)))
+
+			for _, player := range []string{"abc", "def"} {
+				pm, err = client.GetPlayerModel(ctx, &gamepb.PlayerRequest{Me: player})
+				Expect(err).Should(Succeed())
+				pm, err = client.PlayCard(ctx, &gamepb.PlayCardRequest{Me: player, Card: pm.LegalCards[0]})
+				Expect(err).Should(Succeed())
+			}
+			Expect(pm.State).To(Equal("RoundFinished"))
+			Expect(pm.Status.Tricks).To(HaveLen(1))
+			Expect(pm.Status.Tricks[0].Notation).ToNot(BeEmpty())
+			Expect(pm.Status.PreviousHand.GetWinner()).To(Equal(pm.Status.Tricks[0].Winner))
+			pm, err = client.FinishRound(ctx, &gamepb.PlayerRequest{Me: "abc"})
+			Expect(err).Should(Succeed())
+			Expect(pm.State).To(Equal("WaitingForPlayers"))
+		})
+
+		It("should stream a new model whenever the game changes", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			stream, err := client.WatchPlayerModel(ctx, &gamepb.PlayerRequest{Me: "abc"})
+			Expect(err).Should(Succeed())
+			pm, err := stream.Recv()
+			Expect(err).Should(Succeed())
+			Expect(pm.State).To(Equal("NotJoined"))
+
+			_, err = client.Join(ctx, &gamepb.JoinRequest{Me: "abc"})
+			Expect(err).Should(Succeed())
+			pm, err = stream.Recv()
+			Expect(err).Should(Succeed())
+			Expect(pm.Me).To(Equal("abc"))
+			Expect(pm.Version).To(Equal(int32(1)))
+
+			_, err = client.Join(ctx, &gamepb.JoinRequest{Me: "def"})
+			Expect(err).Should(Succeed())
+			pm, err = stream.Recv()
+			Expect(err).Should(Succeed())
+			Expect(pm.Game.Players).To(Equal([]string{"abc", "def"}))
+		})
+
+		It("should end watch streams on shutdown", func() {
+			stream, err := client.WatchPlayerModel(context.Background(), &gamepb.PlayerRequest{Me: "abc"})
+			Expect(err).Should(Succeed())
+			_, err = stream.Recv()
+			Expect(err).Should(Succeed())
+			Expect(server.Shutdown(context.Background())).Should(Succeed())
+			_, err = stream.Recv()
+			Expect(status.Code(err)).To(Equal(codes.Unavailable))
+		})
+	})
+}
@@ -1,10 +1,19 @@
 package game
 
+import "time"
+
+// CardPlay is a single card played in a hand, recorded in the order it hit the table.
+type CardPlay struct {
+	Player string
+	Card   *Card
+	Time   time.Time
+}
+
 type Hand struct {
 	Guid         string
 	Deck         Deck
 	TrumpSuit    string
-	CardsPlayed  map[string]*Card
+	Plays        []*CardPlay
 	PlayersOrder []string
 	Suit         string
 	Leader       string
@@ -16,7 +25,7 @@ func NewHand(deck Deck, trumpSuit string, playersOrder []string) *Hand {
 		Guid:         NewGuid(),
 		Deck:         deck,
 		TrumpSuit:    trumpSuit,
-		CardsPlayed:  map[string]*Card{},
+		Plays:        []*CardPlay{},
 		PlayersOrder: playersOrder,
 		Suit:         "",
 		Leader:       "",
@@ -24,44 +33,58 @@ func NewHand(deck Deck, trumpSuit string, playersOrder []string) *Hand {
 	}
 }
 
+// cardPlayedBy returns nil if the player hasn't played a card yet
+func (hand *Hand) cardPlayedBy(player string) *Card {
+	for _, play := range hand.Plays {
+		if play.Player == player {
+			return play.Card
+		}
+	}
+	return nil
+}
+
 func (hand *Hand) PlayCard(player string, card *Card) {
-	cardsPlayed := len(hand.CardsPlayed)
-	hand.CardsPlayed[player] = card
-	if cardsPlayed == 0 {
+	if len(hand.Plays) == 0 {
 		hand.Suit = card.Suit
-		hand.Leader = player
-		hand.LeaderCard = card
-	} else {
-		// which suit is better?  trump > following suit > something else
-		if card.Suit == hand.TrumpSuit && hand.LeaderCard.Suit == hand.TrumpSuit {
-			// 1. both trumps -- use numbers
-			if hand.Deck.CompareNumbers(hand.LeaderCard.Number, card.Number) < 0 {
-				hand.Leader = player
-				hand.LeaderCard = card
-			}
-		} else if card.Suit == hand.TrumpSuit && hand.LeaderCard.Suit != hand.TrumpSuit {
-			// 2. new card is a trump, old one isn't
-			hand.Leader = player
-			hand.LeaderCard = card
-		} else if card.Suit != hand.TrumpSuit && hand.LeaderCard.Suit == hand.TrumpSuit {
-			// 3. old card is a trump, new one isn't
-			// nothing to do
-		} else if card.Suit == hand.Suit && hand.LeaderCard.Suit == hand.Suit {
-			// 4. both following suit
-			if hand.Deck.CompareNumbers(hand.LeaderCard.Number, card.Number) < 0 {
-				hand.Leader = player
-				hand.LeaderCard = card
-			}
-		} else if card.Suit == hand.Suit && hand.LeaderCard.Suit != hand.Suit {
-			// 5. new card follows suit, old one doesn't
-			hand.Leader = player
-			hand.LeaderCard = card
-		} else if card.Suit != hand.Suit && hand.LeaderCard.Suit == hand.Suit {
-			// 6. old card follows suit, new one doesn't
-			// nothing to do
-		} else {
-			// 7. new card can't possibly be better
-			// nothing to do
+	}
+	hand.Plays = append(hand.Plays, &CardPlay{Player: player, Card: card, Time: time.Now()})
+	hand.Leader, hand.LeaderCard = hand.winner()
+}
+
+// winner replays the cards in the order they were played, to find who's currently winning
+func (hand *Hand) winner() (string, *Card) {
+	leader, leaderCard := "", (*Card)(nil)
+	for _, play := range hand.Plays {
+		if leaderCard == nil || hand.beats(play.Card, leaderCard) {
+			leader, leaderCard = play.Player, play.Card
 		}
 	}
+	return leader, leaderCard
+}
+
+// beats is true if card is better than leaderCard, which was played earlier
+func (hand *Hand) beats(card *Card, leaderCard *Card) bool {
+	// which suit is better?  trump > following suit > something else
+	if card.Suit == hand.TrumpSuit && leaderCard.Suit == hand.TrumpSuit {
+		// 1. both trumps -- use numbers
+		return hand.Deck.CompareNumbers(leaderCard.Number, card.Number) < 0
+	} else if card.Suit == hand.TrumpSuit && leaderCard.Suit != hand.TrumpSuit {
+		// 2. new card is a trump, old one isn't
+		return true
+	} else if card.Suit != hand.TrumpSuit && leaderCard.Suit == hand.TrumpSuit {
+		// 3. old card is a trump, new one isn't
+		return false
+	} else if card.Suit == hand.Suit && leaderCard.Suit == hand.Suit {
+		// 4. both following suit
+		return hand.Deck.CompareNumbers(leaderCard.Number, card.Number) < 0
+	} else if card.Suit == hand.Suit && leaderCard.Suit != hand.Suit {
+		// 5. new card follows suit, old one doesn't
+		return true
+	} else if card.Suit != hand.Suit && leaderCard.Suit == hand.Suit {
+		// 6. old card follows suit, new one doesn't
+		return false
+	} else {
+		// 7. new card can't possibly be better
+		return false
+	}
 }
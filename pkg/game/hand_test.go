@@ -24,6 +24,13 @@ func RunHandTests() {
 
 	Describe("Hand", func() {
 		deck := NewDeterministicShuffleDeck()
+		playedCards := func(hand *Hand) []string {
+			played := []string{}
+			for _, play := range hand.Plays {
+				played = append(played, play.Player+":"+play.Card.Key())
+			}
+			return played
+		}
 		players := []string{"ned", "homer", "karina"}
 
 		Describe("initialization", func() {
@@ -35,7 +42,7 @@ func RunHandTests() {
 			It("should have other fields empty", func() {
 				hand := NewHand(deck, "Clubs", players)
 				Expect(hand.Suit).To(Equal(""))
-				Expect(hand.CardsPlayed).To(Equal(map[string]*Card{}))
+				Expect(hand.Plays).To(BeEmpty())
 				Expect(hand.Leader).To(Equal(""))
 				Expect(hand.LeaderCard).To(BeNil())
 			})
@@ -49,9 +56,7 @@ func RunHandTests() {
 
 				Expect(hand.Leader).To(Equal("abc"))
 				Expect(hand.LeaderCard).To(Equal(nineOfDiamonds))
-				Expect(hand.CardsPlayed).To(Equal(map[string]*Card{
-					"abc": nineOfDiamonds,
-				}))
+				Expect(playedCards(hand)).To(Equal([]string{"abc:Diamonds-9"}))
 				Expect(hand.Suit).To(Equal("Diamonds"))
 			})
 
@@ -63,10 +68,7 @@ func RunHandTests() {
 
 				Expect(hand.Leader).To(Equal("def"))
 				Expect(hand.LeaderCard).To(Equal(threeOfClubs))
-				Expect(hand.CardsPlayed).To(Equal(map[string]*Card{
-					"abc": nineOfDiamonds,
-					"def": threeOfClubs,
-				}))
+				Expect(playedCards(hand)).To(Equal([]string{"abc:Diamonds-9", "def:Clubs-3"}))
 				Expect(hand.Suit).To(Equal("Diamonds"))
 			})
 
@@ -78,10 +80,7 @@ func RunHandTests() {
 
 				Expect(hand.Leader).To(Equal("ghi"))
 				Expect(hand.LeaderCard).To(Equal(jackOfClubs))
-				Expect(hand.CardsPlayed).To(Equal(map[string]*Card{
-					"abc": threeOfClubs,
-					"ghi": jackOfClubs,
-				}))
+				Expect(playedCards(hand)).To(Equal([]string{"abc:Clubs-3", "ghi:Clubs-J"}))
 				Expect(hand.Suit).To(Equal("Clubs"))
 			})
 
@@ -93,12 +92,31 @@ func RunHandTests() {
 
 				Expect(hand.Leader).To(Equal("abc"))
 				Expect(hand.LeaderCard).To(Equal(threeOfClubs))
-				Expect(hand.CardsPlayed).To(Equal(map[string]*Card{
-					"abc": threeOfClubs,
-					"def": kingOfHearts,
-				}))
+				Expect(playedCards(hand)).To(Equal([]string{"abc:Clubs-3", "def:Hearts-K"}))
 				Expect(hand.Suit).To(Equal("Clubs"))
 			})
+
+			It("Should pick the winner from the cards in the order they were played", func() {
+				hand := NewHand(deck, "Hearts", players)
+
+				hand.PlayCard("ned", threeOfClubs)
+				hand.PlayCard("homer", jackOfClubs)
+				leader, leaderCard := hand.winner()
+				Expect(leader).To(Equal("homer"))
+				Expect(leaderCard).To(Equal(jackOfClubs))
+
+				// the last card played is a trump, so it takes the hand
+				hand.PlayCard("karina", kingOfHearts)
+
+				Expect(playedCards(hand)).To(Equal([]string{"ned:Clubs-3", "homer:Clubs-J", "karina:Hearts-K"}))
+				leader, leaderCard = hand.winner()
+				Expect(leader).To(Equal("karina"))
+				Expect(leaderCard).To(Equal(kingOfHearts))
+				Expect(hand.Leader).To(Equal(leader))
+				Expect(hand.LeaderCard).To(Equal(leaderCard))
+				Expect(hand.cardPlayedBy("homer")).To(Equal(jackOfClubs))
+				Expect(hand.cardPlayedBy("nobody")).To(BeNil())
+			})
 		})
 	})
 }
@@ -0,0 +1,40 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"net/http"
+	"time"
+)
+
+// healthCheckTimeout is how long a game's action processor gets to show it's alive
+const healthCheckTimeout = 2 * time.Second
+
+// ErrNotReady is returned while the default game's state is still being loaded
+var ErrNotReady = newGameError(ErrorCodeUnavailable, nil, "game state is still loading")
+
+// setupHealthRoutes serves probes for kubernetes: /healthz fails if any game has stopped
+// processing actions, and /readyz fails until the default game has been loaded.
+func setupHealthRoutes(mux *http.ServeMux, registry *GameRegistry) {
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), healthCheckTimeout)
+		defer cancel()
+		for _, game := range registry.all() {
+			if err := game.Responder.Ping(ctx); err != nil {
+				log.Errorf("game %s failed health check: %+v", game.ID, err)
+				writeError(w, newGameError(ErrorCodeUnavailable, map[string]interface{}{"Game": game.ID}, "game %s isn't processing actions: %s", game.ID, err.Error()))
+				return
+			}
+		}
+		fmt.Fprint(w, "ok")
+	})
+
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := registry.game(DefaultGameID); !ok {
+			writeError(w, ErrNotReady)
+			return
+		}
+		fmt.Fprint(w, "ok")
+	})
+}
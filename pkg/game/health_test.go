@@ -0,0 +1,73 @@
+package game
+
+import (
+	"context"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+func RunHealthTests() {
+	Describe("Health", func() {
+		ctx := context.Background()
+
+		probe := func(mux *http.ServeMux, path string) int {
+			recorder := httptest.NewRecorder()
+			mux.ServeHTTP(recorder, httptest.NewRequest("GET", path, nil))
+			return recorder.Code
+		}
+
+		It("should only be ready once the default game is loaded", func() {
+			mux := http.NewServeMux()
+			registry := NewGameRegistry()
+			setupHealthRoutes(mux, registry)
+			Expect(probe(mux, "/readyz")).To(Equal(503))
+			Expect(probe(mux, "/healthz")).To(Equal(200))
+
+			stop := make(chan struct{})
+			defer close(stop)
+			registry.Add(DefaultGameID, NewGameConcurrencyWrapper(NewGame(), NewArchive(), stop))
+			Expect(probe(mux, "/readyz")).To(Equal(200))
+			Expect(probe(mux, "/healthz")).To(Equal(200))
+		})
+
+		It("should be unhealthy while an action is stuck", func() {
+			mux := http.NewServeMux()
+			registry := NewGameRegistry()
+			setupHealthRoutes(mux, registry)
+			stop := make(chan struct{})
+			defer close(stop)
+			gcw := NewGameConcurrencyWrapper(NewGame(), NewArchive(), stop)
+			registry.Add(DefaultGameID, gcw)
+
+			unstick := make(chan struct{})
+			go func() {
+				defer GinkgoRecover()
+				Expect(gcw.do(ctx, "stuck", func() error {
+					<-unstick
+					return nil
+				})).Should(Succeed())
+			}()
+			Eventually(func() error {
+				pingCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+				defer cancel()
+				return gcw.Ping(pingCtx)
+			}).Should(Equal(ErrTimeout))
+			Expect(probe(mux, "/healthz")).To(Equal(503))
+
+			close(unstick)
+			Expect(gcw.Ping(ctx)).Should(Succeed())
+			Expect(probe(mux, "/healthz")).To(Equal(200))
+		})
+
+		It("should fail pings once stopped", func() {
+			stop := make(chan struct{})
+			gcw := NewGameConcurrencyWrapper(NewGame(), NewArchive(), stop)
+			close(stop)
+			Eventually(gcw.Stopped).Should(BeClosed())
+			Expect(gcw.Ping(ctx)).To(Equal(ErrStopped))
+		})
+	})
+}
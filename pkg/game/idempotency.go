@@ -0,0 +1,199 @@
+package game
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"net/http"
+	"sync"
+)
+
+const (
+	idempotencyKeysPerPlayer = 20
+	// players are named by clients, so there's no telling how many there'll be; only the most
+	// recently active are remembered
+	idempotencyPlayers = 1000
+)
+
+// idempotentResult is the outcome of an action, kept so that it can be handed out again
+type idempotentResult struct {
+	// done is closed once the action has finished, and Value has been set
+	done  chan struct{}
+	Value interface{}
+}
+
+// isDefinitiveStatus is whether a response with status is the action's final answer.
+// Anything else -- the server timing out, say, or being unavailable -- might well go
+// differently next time, so it isn't worth remembering.
+func isDefinitiveStatus(status int) bool {
+	return status < 500 && status != statusClientClosedRequest
+}
+
+// recordedResponse is everything sent back for an action over HTTP, so that it can be sent again
+type recordedResponse struct {
+	Status int
+	Header http.Header
+	Body   []byte
+}
+
+func (rr *recordedResponse) replay(w http.ResponseWriter) {
+	for key, values := range rr.Header {
+		w.Header()[key] = values
+	}
+	w.WriteHeader(rr.Status)
+	w.Write(rr.Body)
+}
+
+// responseRecorder captures a response as it's written
+type responseRecorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func newResponseRecorder() *responseRecorder {
+	return &responseRecorder{header: http.Header{}, status: http.StatusOK}
+}
+
+func (rec *responseRecorder) Header() http.Header {
+	return rec.header
+}
+
+func (rec *responseRecorder) Write(data []byte) (int, error) {
+	return rec.body.Write(data)
+}
+
+func (rec *responseRecorder) WriteHeader(status int) {
+	rec.status = status
+}
+
+type playerKeys struct {
+	Player string
+	// Order is oldest first
+	Order   []string
+	Results map[string]*idempotentResult
+}
+
+// idempotencyCache remembers the results of each player's most recent actions, by the
+// idempotency key the client sent with them.  That way, a client retrying an action -- perhaps
+// because it timed out waiting for the response -- gets the original result, instead of
+// having the action applied twice.
+type idempotencyCache struct {
+	mutex      sync.Mutex
+	size       int
+	maxPlayers int
+	players    map[string]*list.Element
+	// recent holds each player's keys, most recently used first
+	recent *list.List
+}
+
+func newIdempotencyCache(size int, maxPlayers int) *idempotencyCache {
+	return &idempotencyCache{size: size, maxPlayers: maxPlayers, players: map[string]*list.Element{}, recent: list.New()}
+}
+
+// playerKeys finds player's keys, starting them off if need be, and forgetting the least
+// recently active player to make room.  The caller must hold the mutex.
+func (cache *idempotencyCache) playerKeys(player string) *playerKeys {
+	if element, ok := cache.players[player]; ok {
+		cache.recent.MoveToFront(element)
+		return element.Value.(*playerKeys)
+	}
+	keys := &playerKeys{Player: player, Results: map[string]*idempotentResult{}}
+	cache.players[player] = cache.recent.PushFront(keys)
+	if cache.recent.Len() > cache.maxPlayers {
+		oldest := cache.recent.Remove(cache.recent.Back()).(*playerKeys)
+		delete(cache.players, oldest.Player)
+	}
+	return keys
+}
+
+// begin looks up key for player.  If it's new, an empty result is reserved for the caller
+// to fill in; otherwise, the earlier result is returned, and may still be in progress.
+func (cache *idempotencyCache) begin(player string, key string) (*idempotentResult, bool) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	keys := cache.playerKeys(player)
+	if result, ok := keys.Results[key]; ok {
+		return result, false
+	}
+	result := &idempotentResult{done: make(chan struct{})}
+	keys.Results[key] = result
+	keys.Order = append(keys.Order, key)
+	if len(keys.Order) > cache.size {
+		delete(keys.Results, keys.Order[0])
+		keys.Order = keys.Order[1:]
+	}
+	return result, true
+}
+
+// forget drops the result reserved for player's key, if it's still there, so that the
+// action can be tried again
+func (cache *idempotencyCache) forget(player string, key string, result *idempotentResult) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	element, ok := cache.players[player]
+	if !ok {
+		return
+	}
+	keys := element.Value.(*playerKeys)
+	if keys.Results[key] != result {
+		return
+	}
+	delete(keys.Results, key)
+	for i, k := range keys.Order {
+		if k == key {
+			keys.Order = append(keys.Order[:i:i], keys.Order[i+1:]...)
+			break
+		}
+	}
+}
+
+// once runs an action at most once per key: the first call for a key runs handle, and any
+// repeats get the same value.  handle also reports whether its value is definitive; values
+// that aren't are only shared with repeats that arrive while the first call is in progress,
+// and after that, the key is free to be tried again.  A repeat gives up waiting when ctx is
+// done.
+func (cache *idempotencyCache) once(ctx context.Context, player string, key string, handle func() (interface{}, bool)) (interface{}, error) {
+	result, isNew := cache.begin(player, key)
+	if !isNew {
+		select {
+		case <-result.done:
+			return result.Value, nil
+		case <-ctx.Done():
+			return nil, contextError(ctx)
+		}
+	}
+	var value interface{}
+	definitive := false
+	// even if handle panics, repeats mustn't be left waiting
+	defer func() {
+		result.Value = value
+		if !definitive {
+			cache.forget(player, key, result)
+		}
+		close(result.done)
+	}()
+	value, definitive = handle()
+	return value, nil
+}
+
+// serve handles an HTTP request at most once per key, sending repeats the same response
+func (cache *idempotencyCache) serve(w http.ResponseWriter, r *http.Request, player string, key string, handle func(w http.ResponseWriter)) {
+	value, err := cache.once(r.Context(), player, key, func() (interface{}, bool) {
+		rec := newResponseRecorder()
+		handle(rec)
+		response := &recordedResponse{Status: rec.status, Header: rec.header, Body: rec.body.Bytes()}
+		return response, isDefinitiveStatus(response.Status)
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	response, ok := value.(*recordedResponse)
+	if !ok {
+		// the first request never finished
+		writeError(w, newGameError(ErrorCodeInternal, nil, "unable to handle action"))
+		return
+	}
+	response.replay(w)
+}
@@ -0,0 +1,92 @@
+package game
+
+import (
+	"context"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"net/http"
+	"net/http/httptest"
+)
+
+func RunIdempotencyTests() {
+	Describe("Idempotency", func() {
+		ctx := context.Background()
+
+		It("should only remember each player's most recent keys", func() {
+			cache := newIdempotencyCache(2, 10)
+			first, isNew := cache.begin("abc", "k1")
+			Expect(isNew).To(BeTrue())
+			again, isNew := cache.begin("abc", "k1")
+			Expect(isNew).To(BeFalse())
+			Expect(again).To(BeIdenticalTo(first))
+
+			_, isNew = cache.begin("def", "k1")
+			Expect(isNew).To(BeTrue())
+
+			cache.begin("abc", "k2")
+			cache.begin("abc", "k3")
+			_, isNew = cache.begin("abc", "k1")
+			Expect(isNew).To(BeTrue())
+		})
+
+		It("should only remember the most recently active players", func() {
+			cache := newIdempotencyCache(2, 2)
+			cache.begin("abc", "k1")
+			cache.begin("def", "k1")
+			cache.begin("abc", "k2")
+			cache.begin("ghi", "k1")
+			Expect(cache.players).To(HaveLen(2))
+
+			_, isNew := cache.begin("abc", "k1")
+			Expect(isNew).To(BeFalse())
+			_, isNew = cache.begin("def", "k1")
+			Expect(isNew).To(BeTrue())
+		})
+
+		It("should let an action be retried after a transient failure", func() {
+			cache := newIdempotencyCache(2, 2)
+			statuses := []int{http.StatusServiceUnavailable, http.StatusGatewayTimeout, http.StatusOK, http.StatusTeapot}
+			handled := 0
+			serve := func() int {
+				recorder := httptest.NewRecorder()
+				cache.serve(recorder, httptest.NewRequest("POST", "/action", nil), "abc", "k1", func(w http.ResponseWriter) {
+					w.WriteHeader(statuses[handled])
+					handled++
+				})
+				return recorder.Code
+			}
+
+			Expect(serve()).To(Equal(http.StatusServiceUnavailable))
+			Expect(serve()).To(Equal(http.StatusGatewayTimeout))
+			Expect(serve()).To(Equal(http.StatusOK))
+			Expect(serve()).To(Equal(http.StatusOK))
+			Expect(handled).To(Equal(3))
+		})
+
+		It("should apply a repeated action once, and send back the original response", func() {
+			_, client, stop := newTestServer()
+			defer stop()
+			for _, player := range []string{"abc", "def"} {
+				_, err := client.Join(ctx, player)
+				Expect(err).Should(Succeed())
+			}
+			_, err := client.StartRound(ctx, "abc")
+			Expect(err).Should(Succeed())
+
+			wager := &PlayerAction{Me: "abc", MakeWager: &MakeWagerAction{Hands: 0}, IdempotencyKey: "wager-1"}
+			first, err := client.postAction(ctx, wager)
+			Expect(err).Should(Succeed())
+			Expect(first.Status.NextWagerPlayer).To(Equal("def"))
+			second, err := client.postAction(ctx, wager)
+			Expect(err).Should(Succeed())
+			Expect(second).To(Equal(first))
+
+			// a new key is a new action -- which isn't allowed
+			wager.IdempotencyKey = "wager-2"
+			_, err = client.postAction(ctx, wager)
+			Expect(AsGameError(err).Code).To(Equal(ErrorCodeNotYourTurn))
+			_, err = client.postAction(ctx, wager)
+			Expect(AsGameError(err).Code).To(Equal(ErrorCodeNotYourTurn))
+		})
+	})
+}
@@ -0,0 +1,140 @@
+package game
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"time"
+)
+
+const (
+	actionOutcomeSucceeded = "succeeded"
+	actionOutcomeFailed    = "failed"
+	actionOutcomeConflict  = "conflict"
+	actionOutcomeCancelled = "cancelled"
+
+	roundOutcomePlayed    = "played"
+	roundOutcomeAbandoned = "abandoned"
+)
+
+// turnActions are the actions that take up a player's turn
+var turnActions = map[string]bool{"makeWager": true, "playCard": true}
+
+var (
+	actionPanics = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "upanddowntheriver_action_panics_total",
+		Help: "Number of actions which panicked while being processed",
+	}, []string{"action"})
+	actionsProcessed = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "upanddowntheriver_actions_total",
+		Help: "Number of actions processed, by action and outcome",
+	}, []string{"action", "outcome"})
+	ruleViolations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "upanddowntheriver_rule_violations_total",
+		Help: "Number of actions turned down for breaking the game's rules, by error code",
+	}, []string{"code"})
+	actionQueueSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "upanddowntheriver_action_queue_seconds",
+		Help:    "How long actions wait before the action processor picks them up",
+		Buckets: prometheus.ExponentialBuckets(0.0001, 4, 10),
+	}, []string{"action"})
+	activeGames = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "upanddowntheriver_active_games",
+		Help: "Number of games with at least one player",
+	})
+	activePlayers = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "upanddowntheriver_active_players",
+		Help: "Number of players across all games",
+	})
+	roundsStarted = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "upanddowntheriver_rounds_started_total",
+		Help: "Number of rounds started",
+	})
+	roundsFinished = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "upanddowntheriver_rounds_finished_total",
+		Help: "Number of rounds finished, by whether they were played out or abandoned",
+	}, []string{"outcome"})
+	turnSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "upanddowntheriver_turn_seconds",
+		Help:    "How long players take over their turns, from the previous turn or the start of the round",
+		Buckets: prometheus.ExponentialBuckets(0.5, 2, 12),
+	}, []string{"action"})
+)
+
+func init() {
+	prometheus.MustRegister(actionPanics, actionsProcessed, ruleViolations, actionQueueSeconds,
+		activeGames, activePlayers, roundsStarted, roundsFinished, turnSeconds)
+}
+
+// observeActionApplied counts an action that the processor has applied -- or tried to
+func observeActionApplied(action string, err error) {
+	if err == nil {
+		actionsProcessed.WithLabelValues(action, actionOutcomeSucceeded).Inc()
+		return
+	}
+	actionsProcessed.WithLabelValues(action, actionOutcomeFailed).Inc()
+	if code := AsGameError(err).Code; code != ErrorCodeInternal {
+		ruleViolations.WithLabelValues(string(code)).Inc()
+	}
+}
+
+// roundMarker is just enough of a game to tell, after an action, whether a round started or finished
+type roundMarker struct {
+	round          *Round
+	finishedRounds int
+}
+
+func newRoundMarker(game *Game) roundMarker {
+	return roundMarker{round: game.CurrentRound, finishedRounds: len(game.FinishedRounds)}
+}
+
+// gameMetrics is one game's share of the metrics.  It's only touched by the game's action
+// processor.
+type gameMetrics struct {
+	players     int
+	active      bool
+	turnStarted time.Time
+}
+
+// observeGame updates the game's contribution to the active games and players gauges
+func (metrics *gameMetrics) observeGame(game *Game) {
+	players := len(game.Players)
+	activePlayers.Add(float64(players - metrics.players))
+	metrics.players = players
+	active := players > 0
+	if active != metrics.active {
+		if active {
+			activeGames.Inc()
+		} else {
+			activeGames.Dec()
+		}
+		metrics.active = active
+	}
+}
+
+// observeChange records what action did to game, which looked like before beforehand
+func (metrics *gameMetrics) observeChange(action string, before roundMarker, game *Game, now time.Time) {
+	if before.round != nil && turnActions[action] && !metrics.turnStarted.IsZero() {
+		turnSeconds.WithLabelValues(action).Observe(now.Sub(metrics.turnStarted).Seconds())
+		metrics.turnStarted = now
+	}
+	if before.round != game.CurrentRound {
+		if before.round != nil {
+			if len(game.FinishedRounds) > before.finishedRounds {
+				roundsFinished.WithLabelValues(roundOutcomePlayed).Inc()
+			} else {
+				roundsFinished.WithLabelValues(roundOutcomeAbandoned).Inc()
+			}
+		}
+		if game.CurrentRound != nil {
+			roundsStarted.Inc()
+			metrics.turnStarted = now
+		} else {
+			metrics.turnStarted = time.Time{}
+		}
+	}
+	metrics.observeGame(game)
+}
+
+// retire takes the game's contribution back out of the gauges, once it's no longer being served
+func (metrics *gameMetrics) retire() {
+	metrics.observeGame(&Game{})
+}
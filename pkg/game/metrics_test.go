@@ -0,0 +1,54 @@
+package game
+
+import (
+	"context"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func RunMetricsTests() {
+	Describe("Metrics", func() {
+		ctx := context.Background()
+
+		It("should count actions, rounds, and players", func() {
+			succeeded := func(action string) float64 {
+				return testutil.ToFloat64(actionsProcessed.WithLabelValues(action, actionOutcomeSucceeded))
+			}
+			joins := succeeded("join")
+			notYourTurn := testutil.ToFloat64(ruleViolations.WithLabelValues(string(ErrorCodeNotYourTurn)))
+			started := testutil.ToFloat64(roundsStarted)
+			abandoned := testutil.ToFloat64(roundsFinished.WithLabelValues(roundOutcomeAbandoned))
+			// other games may be retiring from the gauges at any moment, so look at just this game's share
+			share := func(gcw *GameConcurrencyWrapper) gameMetrics {
+				metrics, err := gcw.doForValue(ctx, "getMetrics", func() (interface{}, error) {
+					return gcw.metrics, nil
+				})
+				Expect(err).Should(Succeed())
+				return metrics.(gameMetrics)
+			}
+
+			stop := make(chan struct{})
+			gcw := NewGameConcurrencyWrapper(NewGame(), NewArchive(), stop)
+			for _, player := range []string{"abc", "def"} {
+				_, err := gcw.Join(ctx, player)
+				Expect(err).Should(Succeed())
+			}
+			Expect(share(gcw).players).To(Equal(2))
+			Expect(share(gcw).active).To(BeTrue())
+			Expect(succeeded("join")).To(Equal(joins + 2))
+
+			Expect(gcw.StartRound(ctx)).Should(Succeed())
+			Expect(gcw.MakeWager(ctx, "def", 0)).ShouldNot(Succeed())
+			Expect(testutil.ToFloat64(ruleViolations.WithLabelValues(string(ErrorCodeNotYourTurn)))).To(Equal(notYourTurn + 1))
+			Expect(gcw.ForceFinishRound(ctx)).Should(Succeed())
+			Expect(testutil.ToFloat64(roundsStarted)).To(Equal(started + 1))
+			Expect(testutil.ToFloat64(roundsFinished.WithLabelValues(roundOutcomeAbandoned))).To(Equal(abandoned + 1))
+
+			close(stop)
+			Eventually(gcw.Stopped).Should(BeClosed())
+			Expect(gcw.metrics.players).To(Equal(0))
+			Expect(gcw.metrics.active).To(BeFalse())
+		})
+	})
+}
@@ -0,0 +1,237 @@
+package game
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+const openAPISchemaPrefix = "#/components/schemas/"
+
+// jsonSchema is the part of openapi's schema object that the spec uses.  Validation ignores any
+// keyword that isn't listed here, so the spec mustn't rely on one without adding it.
+type jsonSchema struct {
+	Ref         string                 `json:"$ref"`
+	Type        string                 `json:"type"`
+	Nullable    bool                   `json:"nullable"`
+	Properties  map[string]*jsonSchema `json:"properties"`
+	Required    []string               `json:"required"`
+	Items       *jsonSchema            `json:"items"`
+	Enum        []interface{}          `json:"enum"`
+	OneOf       []*jsonSchema          `json:"oneOf"`
+	Minimum     *float64               `json:"minimum"`
+	Description string                 `json:"description"`
+	// AdditionalProperties is either false, to forbid properties that aren't listed, or a
+	// schema for the values of a map
+	AdditionalProperties json.RawMessage `json:"additionalProperties"`
+}
+
+type openAPIDocument struct {
+	Components struct {
+		Schemas map[string]*jsonSchema `json:"schemas"`
+	} `json:"components"`
+}
+
+// openAPISpec describes the http api.  Its component schemas are named after -- and must
+// match -- the go types they describe; request bodies are validated against them.
+//
+//go:embed openapi.json
+var openAPISpec string
+
+var openAPISchemas = mustParseOpenAPISchemas(openAPISpec)
+
+func mustParseOpenAPISchemas(spec string) map[string]*jsonSchema {
+	document := &openAPIDocument{}
+	if err := json.Unmarshal([]byte(spec), document); err != nil {
+		panic(errors.Wrapf(err, "unable to parse openapi spec"))
+	}
+	return document.Components.Schemas
+}
+
+// validateRequestBody checks a request body against the named schema, so that malformed
+// requests are turned away -- with a message saying what's wrong -- before they reach a game
+func validateRequestBody(schemaName string, body []byte) error {
+	return validateBody(schemaName, body, false)
+}
+
+// validateRequestBodyTypes is validateRequestBody for /action, whose bodies have always been
+// decoded the way encoding/json does it: field names match regardless of case, and unknown
+// fields are ignored.  Only the types of the fields it knows are checked, so that existing
+// clients keep working.
+func validateRequestBodyTypes(schemaName string, body []byte) error {
+	return validateBody(schemaName, body, true)
+}
+
+func validateBody(schemaName string, body []byte, lenient bool) error {
+	schema, ok := openAPISchemas[schemaName]
+	if !ok {
+		return newGameError(ErrorCodeInternal, nil, "no schema %s to validate request against", schemaName)
+	}
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return newGameError(ErrorCodeInvalidRequest, nil, "unable to unmarshal json: %s", err.Error())
+	}
+	if problem := schema.validate("$", value, lenient); problem != nil {
+		return problem
+	}
+	return nil
+}
+
+func invalidBody(path string, format string, args ...interface{}) *GameError {
+	return newGameError(ErrorCodeInvalidRequest, map[string]interface{}{"Path": path}, "invalid request body at %s: %s", path, fmt.Sprintf(format, args...))
+}
+
+func (schema *jsonSchema) resolve() *jsonSchema {
+	for schema.Ref != "" {
+		resolved, ok := openAPISchemas[strings.TrimPrefix(schema.Ref, openAPISchemaPrefix)]
+		if !ok {
+			panic(errors.Errorf("openapi spec refers to missing schema %s", schema.Ref))
+		}
+		schema = resolved
+	}
+	return schema
+}
+
+func (schema *jsonSchema) validate(path string, value interface{}, lenient bool) *GameError {
+	schema = schema.resolve()
+	if value == nil {
+		if schema.Nullable {
+			return nil
+		}
+		return invalidBody(path, "must not be null")
+	}
+	if len(schema.OneOf) > 0 {
+		matches := 0
+		for _, option := range schema.OneOf {
+			if option.validate(path, value, lenient) == nil {
+				matches++
+			}
+		}
+		if matches != 1 {
+			return invalidBody(path, "must match exactly one of %d forms", len(schema.OneOf))
+		}
+		return nil
+	}
+
+	switch schema.Type {
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return invalidBody(path, "must be an object")
+		}
+		return schema.validateObject(path, object, lenient)
+	case "array":
+		array, ok := value.([]interface{})
+		if !ok {
+			return invalidBody(path, "must be an array")
+		}
+		for i, item := range array {
+			if problem := schema.Items.validate(fmt.Sprintf("%s[%d]", path, i), item, lenient); problem != nil {
+				return problem
+			}
+		}
+	case "string":
+		if _, ok := value.(string); !ok {
+			return invalidBody(path, "must be a string")
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return invalidBody(path, "must be a boolean")
+		}
+	case "integer", "number":
+		number, ok := value.(float64)
+		if !ok || (schema.Type == "integer" && number != float64(int64(number))) {
+			return invalidBody(path, "must be an %s", schema.Type)
+		}
+		if schema.Minimum != nil && number < *schema.Minimum {
+			return invalidBody(path, "must be at least %v", *schema.Minimum)
+		}
+	}
+
+	if len(schema.Enum) > 0 {
+		for _, allowed := range schema.Enum {
+			if value == allowed {
+				return nil
+			}
+		}
+		return invalidBody(path, "must be one of %v", schema.Enum)
+	}
+	return nil
+}
+
+// property finds the schema of an object's field, matching its name the way encoding/json
+// does when lenient: an exact match first, then any that matches regardless of case
+func (schema *jsonSchema) property(name string, lenient bool) (string, *jsonSchema) {
+	if fieldSchema, ok := schema.Properties[name]; ok || !lenient {
+		return name, fieldSchema
+	}
+	for property, fieldSchema := range schema.Properties {
+		if strings.EqualFold(property, name) {
+			return property, fieldSchema
+		}
+	}
+	return name, nil
+}
+
+func (schema *jsonSchema) validateObject(path string, object map[string]interface{}, lenient bool) *GameError {
+	present := map[string]bool{}
+	for name := range object {
+		property, _ := schema.property(name, lenient)
+		present[property] = true
+	}
+	for _, name := range schema.Required {
+		if !present[name] {
+			return invalidBody(path, "missing required field %s", name)
+		}
+	}
+	var valueSchema *jsonSchema
+	closed := false
+	if len(schema.AdditionalProperties) > 0 {
+		if string(schema.AdditionalProperties) == "false" {
+			closed = true
+		} else if err := json.Unmarshal(schema.AdditionalProperties, &valueSchema); err != nil {
+			panic(errors.Wrapf(err, "unable to parse additionalProperties at %s", path))
+		}
+	}
+	// sort, so that the first problem reported doesn't depend on map order
+	names := []string{}
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		_, fieldSchema := schema.property(name, lenient)
+		if fieldSchema == nil {
+			fieldSchema = valueSchema
+		}
+		if fieldSchema == nil {
+			if closed && !lenient {
+				return invalidBody(path, "unknown field %s", name)
+			}
+			continue
+		}
+		if problem := fieldSchema.validate(path+"."+name, object[name], lenient); problem != nil {
+			return problem
+		}
+	}
+	return nil
+}
+
+// setupOpenAPIRoute serves the spec, so that client authors needn't guess at the api
+func setupOpenAPIRoute(mux *http.ServeMux) {
+	mux.HandleFunc("/openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		log.Debugf("receiving %s request to %s", r.Method, r.URL.String())
+		if r.Method != "GET" {
+			log.Errorf("verb %s not supported for /openapi.json", r.Method)
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set(http.CanonicalHeaderKey("content-type"), "application/json")
+		fmt.Fprint(w, openAPISpec)
+	})
+}
@@ -0,0 +1,211 @@
+package game
+
+import (
+	"encoding/json"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"reflect"
+	"sort"
+)
+
+// jsonFieldNames lists the fields encoding/json uses for a struct, including those of embedded structs
+func jsonFieldNames(t reflect.Type) []string {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	names := []string{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous {
+			names = append(names, jsonFieldNames(field.Type)...)
+		} else if field.PkgPath == "" {
+			names = append(names, field.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func schemaPropertyNames(name string) []string {
+	names := []string{}
+	for property := range openAPISchemas[name].Properties {
+		names = append(names, property)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// unsetFields lists the exported fields of a struct -- and of the structs it points to -- that
+// hold their zero value, so that a test can insist that a value fills in every field
+func unsetFields(path string, value reflect.Value) []string {
+	if value.Kind() == reflect.Ptr {
+		value = value.Elem()
+	}
+	unset := []string{}
+	t := value.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field, fieldValue := t.Field(i), value.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		fieldPath := path + "." + field.Name
+		if field.Anonymous {
+			fieldPath = path
+		}
+		if fieldValue.IsZero() {
+			unset = append(unset, fieldPath)
+		} else if kind := reflect.Indirect(fieldValue).Kind(); kind == reflect.Struct {
+			unset = append(unset, unsetFields(fieldPath, fieldValue)...)
+		}
+	}
+	return unset
+}
+
+func RunOpenAPITests() {
+	Describe("OpenAPI spec", func() {
+		It("should describe every field of the go types", func() {
+			types := []interface{}{
+				PlayerAction{}, RemovePlayerAction{}, MakeWagerAction{}, SetCardsPerPlayerAction{}, SetDeckTypePlayerAction{},
+				V1Request{}, V1WagerRequest{}, V1CardRequest{}, V1CardsPerPlayerRequest{}, V1DeckTypeRequest{}, V1GameList{},
+				CardPlay{}, PlayerModel{}, PublicModel{}, PlayerGame{}, Status{}, PlayerStatus{}, PreviousHand{}, CurrentHand{}, Trick{},
+				RoundRecord{}, GameRules{}, GameSummary{}, GameRecord{}, ArchivePage{}, GameError{},
+				AdminGame{}, AdminGameList{}, AdminBroadcastRequest{},
+			}
+			for _, value := range types {
+				t := reflect.TypeOf(value)
+				Expect(openAPISchemas).To(HaveKey(t.Name()))
+				Expect(schemaPropertyNames(t.Name())).To(Equal(jsonFieldNames(t)), t.Name())
+			}
+		})
+
+		It("should list every enum value", func() {
+			enums := map[string][]string{}
+			for _, state := range []PlayerState{PlayerStateNotJoined, PlayerStateWaitingForPlayers, PlayerStateWagerTurn, PlayerStatePlayCardTurn, PlayerStateRoundFinished} {
+				enums["PlayerState"] = append(enums["PlayerState"], state.JSONString())
+			}
+			for mood := PlayerMoodNone; mood <= PlayerMoodWon; mood++ {
+				enums["PlayerMood"] = append(enums["PlayerMood"], mood.JSONString())
+			}
+			for _, deckType := range []DeckType{DeckTypeCustom, DeckTypeMini, DeckTypeDoubleMini, DeckTypeStandard, DeckTypeDoubleStandard, DeckTypeDeterministicStandard} {
+				enums["DeckType"] = append(enums["DeckType"], deckType.JSONString())
+			}
+			for name, values := range enums {
+				spec := []string{}
+				for _, value := range openAPISchemas[name].Enum {
+					spec = append(spec, value.(string))
+				}
+				Expect(spec).To(ConsistOf(values), name)
+			}
+		})
+
+		It("should accept what the go client sends", func() {
+			hands := 2
+			actions := []*PlayerAction{
+				{Me: "abc", GetModel: &GetPlayerModelAction{}},
+				{Me: "abc", IdempotencyKey: NewGuid(), ExpectedVersion: &hands, MakeWager: &MakeWagerAction{Hands: hands}},
+				{Me: "abc", PlayCard: &Card{Suit: "Hearts", Number: "Q"}},
+				{Me: "abc", SetDeckType: &SetDeckTypePlayerAction{DeckType: DeckTypeDoubleStandard}},
+			}
+			for _, action := range actions {
+				body, err := json.Marshal(action)
+				Expect(err).Should(Succeed())
+				Expect(validateRequestBody("PlayerAction", body)).Should(Succeed())
+			}
+			Expect(validateRequestBody("PlayerAction", []byte(`{"Me": "abc", "PlayCard": "QH"}`))).Should(Succeed())
+		})
+
+		It("should accept every field of the request types", func() {
+			version := 3
+			v1 := V1Request{Me: "abc", IdempotencyKey: NewGuid(), ExpectedVersion: &version}
+			// every field must be set, so that a field missing from the spec can't slip through
+			requests := []interface{}{
+				&PlayerAction{
+					Me:                "abc",
+					IdempotencyKey:    NewGuid(),
+					ExpectedVersion:   &version,
+					GetModel:          &GetPlayerModelAction{},
+					Join:              &JoinAction{},
+					MakeWager:         &MakeWagerAction{Hands: 2},
+					PlayCard:          &Card{Suit: "Hearts", Number: "Q"},
+					RemovePlayer:      &RemovePlayerAction{Player: "def"},
+					SetCardsPerPlayer: &SetCardsPerPlayerAction{Count: 4},
+					SetDeckType:       &SetDeckTypePlayerAction{DeckType: DeckTypeDoubleStandard},
+					StartRound:        &StartRoundAction{},
+					FinishRound:       &FinishRoundAction{},
+					FinishGame:        &FinishGameAction{},
+				},
+				&v1,
+				&V1WagerRequest{V1Request: v1, Hands: 2},
+				&V1CardRequest{V1Request: v1, Card: &Card{Suit: "Spades", Number: "10"}},
+				&V1CardsPerPlayerRequest{V1Request: v1, Count: 4},
+				&V1DeckTypeRequest{V1Request: v1, DeckType: DeckTypeMini},
+				&AdminBroadcastRequest{Message: "back in five"},
+			}
+			for _, request := range requests {
+				name := reflect.TypeOf(request).Elem().Name()
+				Expect(unsetFields(name, reflect.ValueOf(request))).To(BeEmpty())
+				body, err := json.Marshal(request)
+				Expect(err).Should(Succeed())
+				Expect(validateRequestBody(name, body)).Should(Succeed(), name)
+			}
+		})
+
+		It("should reject malformed requests, saying where the problem is", func() {
+			cases := map[string]string{
+				`{"Me": "abc", "MakeWager": {"Hands": "two"}}`:        "$.MakeWager.Hands",
+				`{"Me": "abc", "MakeWager": {"Hands": -1}}`:           "$.MakeWager.Hands",
+				`{"Me": "abc", "MakeWager": {}}`:                      "$.MakeWager",
+				`{"Me": "abc", "SetDeckType": {"DeckType": "Tarot"}}`: "$.SetDeckType.DeckType",
+				`{"Me": "abc", "PlayCard": {"Suit": "Hearts"}}`:       "$.PlayCard",
+				`{"Me": "abc", "Wager": {"Hands": 1}}`:                "$",
+				`["Me"]`:                                              "$",
+				`{"Me": "abc", "ExpectedVersion": 1.5, "Join": {}}`:   "$.ExpectedVersion",
+				`{"Me": "abc", "RemovePlayer": {"Player": null}}`:     "$.RemovePlayer.Player",
+			}
+			for body, path := range cases {
+				err := validateRequestBody("PlayerAction", []byte(body))
+				Expect(err).ShouldNot(Succeed(), body)
+				Expect(AsGameError(err).Code).To(Equal(ErrorCodeInvalidRequest))
+				Expect(AsGameError(err).Details["Path"]).To(Equal(path), body)
+			}
+		})
+
+		It("should only check the types of the fields it knows, for /action", func() {
+			lenient := []string{
+				`{"me": "abc", "makewager": {"hands": 2}}`,
+				`{"Me": "abc", "Join": {}, "Color": "red"}`,
+				`{"Me": "abc", "Wager": {"Hands": 1}}`,
+			}
+			for _, body := range lenient {
+				Expect(validateRequestBodyTypes("PlayerAction", []byte(body))).Should(Succeed(), body)
+				Expect(validateRequestBody("PlayerAction", []byte(body))).ShouldNot(Succeed(), body)
+			}
+
+			err := validateRequestBodyTypes("PlayerAction", []byte(`{"me": "abc", "makeWager": {"hands": "two"}}`))
+			Expect(AsGameError(err).Code).To(Equal(ErrorCodeInvalidRequest))
+			Expect(AsGameError(err).Details["Path"]).To(Equal("$.makeWager.hands"))
+			err = validateRequestBodyTypes("PlayerAction", []byte(`{"Me": "abc", "playCard": {"suit": "Hearts"}}`))
+			Expect(AsGameError(err).Details["Path"]).To(Equal("$.playCard"))
+		})
+
+		It("should be served, and checked before requests are dispatched", func() {
+			_, client, stop := newTestServer()
+			defer stop()
+			resp, err := client.Resty.R().Get(client.url("openapi.json"))
+			Expect(err).Should(Succeed())
+			Expect(resp.StatusCode()).To(Equal(200))
+			Expect(resp.Body()).To(MatchJSON(openAPISpec))
+
+			// /action still takes what encoding/json would, but turns away the wrong types
+			resp, err = client.Resty.R().SetBody(`{"me": "abc", "join": {}, "Color": "red"}`).Post(client.url("action"))
+			Expect(err).Should(Succeed())
+			Expect(resp.StatusCode()).To(Equal(200))
+
+			resp, err = client.Resty.R().SetBody(`{"Me": "abc", "MakeWager": {"Hands": "two"}}`).Post(client.url("action"))
+			Expect(err).Should(Succeed())
+			gameError := AsGameError(responseError("action", resp))
+			Expect(gameError.Code).To(Equal(ErrorCodeInvalidRequest))
+			Expect(gameError.Details["Path"]).To(Equal("$.MakeWager.Hands"))
+		})
+	})
+}
@@ -0,0 +1,146 @@
+package game
+
+import (
+	"encoding/json"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// decks are serialized as whatever their underlying struct is, but every predefined deck has a
+// Type -- which is all that's needed to rebuild it
+type deckJson struct {
+	Type DeckType
+}
+
+func (dj *deckJson) deck() (Deck, error) {
+	if dj == nil {
+		return nil, errors.New("missing deck")
+	}
+	return NewDeck(dj.Type)
+}
+
+func (game *Game) UnmarshalJSON(data []byte) error {
+	type gameJson Game
+	aux := &struct {
+		*gameJson
+		Deck *deckJson
+	}{gameJson: (*gameJson)(game)}
+	err := json.Unmarshal(data, aux)
+	if err != nil {
+		return err
+	}
+	game.Deck, err = aux.Deck.deck()
+	return errors.WithMessagef(err, "unable to restore game deck")
+}
+
+func (round *Round) UnmarshalJSON(data []byte) error {
+	type roundJson Round
+	aux := &struct {
+		*roundJson
+		Deck *deckJson
+	}{roundJson: (*roundJson)(round)}
+	err := json.Unmarshal(data, aux)
+	if err != nil {
+		return err
+	}
+	round.Deck, err = aux.Deck.deck()
+	return errors.WithMessagef(err, "unable to restore deck for round %s", round.Guid)
+}
+
+func (hand *Hand) UnmarshalJSON(data []byte) error {
+	type handJson Hand
+	aux := &struct {
+		*handJson
+		Deck *deckJson
+	}{handJson: (*handJson)(hand)}
+	err := json.Unmarshal(data, aux)
+	if err != nil {
+		return err
+	}
+	hand.Deck, err = aux.Deck.deck()
+	return errors.WithMessagef(err, "unable to restore deck for hand %s", hand.Guid)
+}
+
+// SaveGame writes the game to path, replacing whatever was there only once the new state has
+// been completely written.
+func SaveGame(path string, game *Game) error {
+	bytes, err := json.MarshalIndent(game, "", "  ")
+	if err != nil {
+		return errors.Wrapf(err, "unable to serialize game")
+	}
+	return writeFileAtomically(path, bytes)
+}
+
+// SaveArchive writes the archive to path, in the same way as SaveGame.
+func SaveArchive(path string, archive *Archive) error {
+	bytes, err := json.MarshalIndent(archive, "", "  ")
+	if err != nil {
+		return errors.Wrapf(err, "unable to serialize archive")
+	}
+	return writeFileAtomically(path, bytes)
+}
+
+func writeFileAtomically(path string, bytes []byte) error {
+	tmpFile, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return errors.Wrapf(err, "unable to create temp file for %s", path)
+	}
+	_, err = tmpFile.Write(bytes)
+	if err == nil {
+		err = tmpFile.Sync()
+	}
+	closeErr := tmpFile.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmpFile.Name())
+		return errors.Wrapf(err, "unable to write %s", tmpFile.Name())
+	}
+	return errors.Wrapf(os.Rename(tmpFile.Name(), path), "unable to move state into %s", path)
+}
+
+// LoadGame reads a game saved by SaveGame.  If there's nothing at path yet, it starts a new game.
+func LoadGame(path string) (*Game, error) {
+	bytes, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		log.Infof("no saved state found at %s, starting a new game", path)
+		return NewGame(), nil
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "unable to read %s", path)
+	}
+	game := &Game{}
+	err = json.Unmarshal(bytes, game)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to deserialize game from %s", path)
+	}
+	if game.PlayersSet == nil {
+		game.PlayersSet = map[string]bool{}
+	}
+	return game, nil
+}
+
+// LoadArchive reads an archive saved by SaveArchive.  If there's nothing at path yet, it starts
+// an empty archive.
+func LoadArchive(path string) (*Archive, error) {
+	bytes, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		log.Infof("no saved archive found at %s, starting an empty archive", path)
+		return NewArchive(), nil
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "unable to read %s", path)
+	}
+	archive := NewArchive()
+	err = json.Unmarshal(bytes, archive)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to deserialize archive from %s", path)
+	}
+	if archive.Games == nil {
+		archive.Games = []*GameRecord{}
+	}
+	archive.trim()
+	return archive, nil
+}
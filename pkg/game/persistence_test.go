@@ -0,0 +1,99 @@
+package game
+
+import (
+	"encoding/json"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+func RunPersistenceTests() {
+	Describe("Persistence", func() {
+		var dir string
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "upanddowntheriver")
+			Expect(err).Should(Succeed())
+		})
+
+		AfterEach(func() {
+			Expect(os.RemoveAll(dir)).Should(Succeed())
+		})
+
+		It("should start a new game if there's no saved state", func() {
+			game, err := LoadGame(filepath.Join(dir, "state.json"))
+			Expect(err).Should(Succeed())
+			Expect(game.State).To(Equal(GameStateSetup))
+			Expect(game.Players).To(BeEmpty())
+		})
+
+		It("should restore a game in the middle of a round", func() {
+			game := NewGame()
+			game.Deck = NewDeterministicShuffleDeck()
+			Expect(joinGame(game, "abc")).Should(Succeed())
+			Expect(joinGame(game, "def")).Should(Succeed())
+			Expect(game.setCardsPerPlayer(2)).Should(Succeed())
+			Expect(game.startRound()).Should(Succeed())
+			Expect(game.makeWager("abc", 0)).Should(Succeed())
+			Expect(game.makeWager("def", 0)).Should(Succeed())
+			Expect(game.playCard("abc", &Card{Suit: "Clubs", Number: "2"})).Should(Succeed())
+
+			path := filepath.Join(dir, "state.json")
+			Expect(SaveGame(path, game)).Should(Succeed())
+			restored, err := LoadGame(path)
+			Expect(err).Should(Succeed())
+
+			Expect(restored.Guid).To(Equal(game.Guid))
+			Expect(restored.State).To(Equal(GameStateRoundInProgress))
+			Expect(restored.Deck.DeckType()).To(Equal(DeckTypeDeterministicStandard))
+			Expect(restored.CurrentRound.State).To(Equal(RoundStateHandInProgress))
+			Expect(restored.CurrentRound.Wagers).To(Equal(map[string]int{"abc": 0, "def": 0}))
+			Expect(restored.CurrentRound.CurrentHand.cardPlayedBy("abc")).To(Equal(&Card{Suit: "Clubs", Number: "2"}))
+			// compare the serialized models, since times lose their monotonic clock readings in a round trip
+			restoredModel, err := json.Marshal(restored.playerModel("def"))
+			Expect(err).Should(Succeed())
+			originalModel, err := json.Marshal(game.playerModel("def"))
+			Expect(err).Should(Succeed())
+			Expect(restoredModel).To(MatchJSON(originalModel))
+
+			// and play continues where it left off
+			Expect(restored.playCard("def", &Card{Suit: "Clubs", Number: "3"})).Should(Succeed())
+			Expect(restored.CurrentRound.FinishedHands).To(HaveLen(1))
+		})
+
+		It("should restore the archive separately from the game", func() {
+			archive, err := LoadArchive(filepath.Join(dir, "archive.json"))
+			Expect(err).Should(Succeed())
+			Expect(archive.Games).To(BeEmpty())
+
+			game := NewGame()
+			game.Deck = NewDeterministicShuffleDeck()
+			Expect(joinGame(game, "abc")).Should(Succeed())
+			Expect(joinGame(game, "def")).Should(Succeed())
+			Expect(game.startRound()).Should(Succeed())
+			Expect(game.makeWager("abc", 0)).Should(Succeed())
+			Expect(game.makeWager("def", 0)).Should(Succeed())
+			Expect(game.playCard("abc", &Card{Suit: "Clubs", Number: "2"})).Should(Succeed())
+			Expect(game.playCard("def", &Card{Suit: "Clubs", Number: "3"})).Should(Succeed())
+			Expect(game.finishRound()).Should(Succeed())
+			guid := game.Guid
+			Expect(game.finishGame(archive)).Should(Succeed())
+
+			path := filepath.Join(dir, "archive.json")
+			Expect(SaveArchive(path, archive)).Should(Succeed())
+			Expect(SaveGame(filepath.Join(dir, "state.json"), game)).Should(Succeed())
+			state, err := ioutil.ReadFile(filepath.Join(dir, "state.json"))
+			Expect(err).Should(Succeed())
+			Expect(string(state)).ToNot(ContainSubstring(guid))
+
+			restored, err := LoadArchive(path)
+			Expect(err).Should(Succeed())
+			record, err := restored.game(guid)
+			Expect(err).Should(Succeed())
+			Expect(record.Players).To(Equal([]string{"abc", "def"}))
+		})
+	})
+}
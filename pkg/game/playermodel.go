@@ -83,6 +83,8 @@ func (p *PlayerState) UnmarshalText(text []byte) (err error) {
 }
 
 type PlayerGame struct {
+	// Guid identifies the game; versions only go up for the same guid
+	Guid              string
 	Players           []string
 	MaxCardsPerPlayer int
 	CardsPerPlayer    int
@@ -94,6 +96,7 @@ type CurrentHand struct {
 	Leader     string
 	LeaderCard *Card
 	NextPlayer string
+	Plays      []*CardPlay
 }
 
 type PlayerStatus struct {
@@ -122,32 +125,71 @@ type Status struct {
 	WagerSum        int
 	PreviousHand    *PreviousHand
 	CurrentHand     *CurrentHand
+	Tricks          []*Trick
 }
 
 type PlayerModel struct {
+	// Version is the version of the game this model was built from
+	Version int
 	Me      string
 	State   PlayerState
 	Game    *PlayerGame
 	Status  *Status
 	MyCards []*Card
+	// LegalWagers is only set when it's my turn to wager
+	LegalWagers []int
+	// LegalCards is only set when it's my turn to play a card
+	LegalCards []*Card
+	// Announcement is a message from the admins, if there is one
+	Announcement string
 }
 
-func newPlayerModel(game *Game, player string) *PlayerModel {
+// PublicModel is what anyone can see of a game: everything that's on the table, but nobody's cards
+type PublicModel struct {
+	Version      int
+	State        PlayerState
+	Game         *PlayerGame
+	Status       *Status
+	Announcement string
+}
+
+func newPublicModel(game *Game) *PublicModel {
+	pm := &PublicModel{
+		Version:      game.Version,
+		State:        PlayerStateWaitingForPlayers,
+		Game:         newPlayerGame(game),
+		Announcement: game.Announcement,
+	}
+	if game.State == GameStateRoundInProgress {
+		// nobody is "me", so every player's status is seen from the outside
+		pm.State, pm.Status = roundStatus(game, "")
+	}
+	return pm
+}
+
+func newPlayerGame(game *Game) *PlayerGame {
 	maxCardsPerPlayer := 1
 	if len(game.Players) > 0 {
 		maxCardsPerPlayer = game.Deck.Size() / len(game.Players)
 	}
-	pg := &PlayerGame{
-		Players:           game.Players,
+	return &PlayerGame{
+		Guid:              game.Guid,
+		Players:           append([]string{}, game.Players...),
 		MaxCardsPerPlayer: maxCardsPerPlayer,
 		CardsPerPlayer:    game.CardsPerPlayer,
 		DeckType:          game.Deck.DeckType(),
 	}
+}
+
+func newPlayerModel(game *Game, player string) *PlayerModel {
+	pg := newPlayerGame(game)
 	// empty player, or player not found?  we'll only let them see who's playing and the game config
 	if _, ok := game.PlayersSet[player]; !ok {
 		return &PlayerModel{
-			State: PlayerStateNotJoined,
-			Game:  pg,
+			Version:      game.Version,
+			State:        PlayerStateNotJoined,
+			Game:         pg,
+			Announcement: game.Announcement,
 		}
 	}
 
@@ -161,13 +203,24 @@ func newPlayerModel(game *Game, player string) *PlayerModel {
 	case GameStateRoundInProgress:
 		state, status, myCards = playerStatusAndCards(game, player)
 	}
-	return &PlayerModel{
-		Me:      player,
-		State:   state,
-		Game:    pg,
-		Status:  status,
-		MyCards: myCards,
+	pm := &PlayerModel{
+		Version:      game.Version,
+		Me:           player,
+		State:        state,
+		Game:         pg,
+		Status:       status,
+		MyCards:      myCards,
+		Announcement: game.Announcement,
 	}
+	if game.State == GameStateRoundInProgress {
+		if wagers := game.CurrentRound.legalWagers(player); len(wagers) > 0 {
+			pm.LegalWagers = wagers
+		}
+		if cards := game.CurrentRound.legalCards(player); len(cards) > 0 {
+			pm.LegalCards = cards
+		}
+	}
+	return pm
 }
 
 func playerStatusAndCards(game *Game, player string) (PlayerState, *Status, []*Card) {
@@ -177,14 +230,13 @@ func playerStatusAndCards(game *Game, player string) (PlayerState, *Status, []*C
 	sort.Slice(cards, func(i, j int) bool {
 		return game.Deck.Compare(cards[i], cards[j]) < 0
 	})
+	state, status := roundStatus(game, player)
+	return state, status, cards
+}
 
-	playerWins := map[string]int{}
-	for _, hand := range game.CurrentRound.FinishedHands {
-		if _, ok := playerWins[hand.Leader]; !ok {
-			playerWins[hand.Leader] = 0
-		}
-		playerWins[hand.Leader]++
-	}
+// roundStatus is the current round as player sees it -- which, apart from IsMe, is the same for everyone
+func roundStatus(game *Game, player string) (PlayerState, *Status) {
+	playerWins := game.CurrentRound.HandsWon()
 	var prevHand *Hand
 	if len(game.CurrentRound.FinishedHands) > 0 {
 		prevHand = game.CurrentRound.FinishedHands[len(game.CurrentRound.FinishedHands)-1]
@@ -218,11 +270,11 @@ func playerStatusAndCards(game *Game, player string) (PlayerState, *Status, []*C
 			Mood:          PlayerMoodNone,
 		}
 		if prevHand != nil {
-			ps.PreviousCard = prevHand.CardsPlayed[p]
+			ps.PreviousCard = prevHand.cardPlayedBy(p)
 			ps.IsPreviousWinner = prevHand.Leader == p
 		}
 		if currHand != nil {
-			ps.CurrentCard = currHand.CardsPlayed[p]
+			ps.CurrentCard = currHand.cardPlayedBy(p)
 			ps.IsCurrentLeader = currHand.Leader == p
 		}
 		playerStatuses = append(playerStatuses, ps)
@@ -232,6 +284,7 @@ func playerStatusAndCards(game *Game, player string) (PlayerState, *Status, []*C
 		TrumpSuit:       game.CurrentRound.TrumpSuit,
 		WagerSum:        game.CurrentRound.WagerSum,
 		NextWagerPlayer: nextWagerPlayer,
+		Tricks:          newTricks(game.CurrentRound),
 	}
 	if prevHand != nil {
 		status.PreviousHand = &PreviousHand{
@@ -250,8 +303,7 @@ func playerStatusAndCards(game *Game, player string) (PlayerState, *Status, []*C
 		ch := game.CurrentRound.CurrentHand
 		nextPlayer := ""
 		for _, p := range ch.PlayersOrder {
-			_, ok := ch.CardsPlayed[p]
-			if !ok && nextPlayer == "" {
+			if ch.cardPlayedBy(p) == nil && nextPlayer == "" {
 				nextPlayer = p
 				break
 			}
@@ -266,6 +318,7 @@ func playerStatusAndCards(game *Game, player string) (PlayerState, *Status, []*C
 			Leader:     ch.Leader,
 			LeaderCard: ch.LeaderCard,
 			NextPlayer: nextPlayer,
+			Plays:      append([]*CardPlay{}, ch.Plays...),
 		}
 		break
 	case RoundStateFinished:
@@ -290,7 +343,7 @@ func playerStatusAndCards(game *Game, player string) (PlayerState, *Status, []*C
 		}
 	}
 
-	return state, status, cards
+	return state, status
 }
 
 func lostMood(miss int) PlayerMood {
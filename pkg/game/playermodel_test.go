@@ -1,6 +1,7 @@
 package game
 
 import (
+	"encoding/json"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -86,5 +87,28 @@ func RunPlayerModelTests() {
 			Expect(playerMood(0, 0, 2, 4, true, true)).To(Equal(PlayerMoodScared))
 		})
 
+		It("should show the table, but nobody's cards, in the public model", func() {
+			game := NewGame()
+			for _, player := range []string{"abc", "def"} {
+				_, err := game.join(player)
+				Expect(err).Should(Succeed())
+			}
+			Expect(newPublicModel(game).Status).To(BeNil())
+			Expect(game.setCardsPerPlayer(2)).Should(Succeed())
+			Expect(game.startRound()).Should(Succeed())
+			Expect(game.makeWager("abc", 1)).Should(Succeed())
+
+			public := newPublicModel(game)
+			Expect(public.State).To(Equal(PlayerStateWagerTurn))
+			Expect(public.Game.Players).To(Equal([]string{"abc", "def"}))
+			Expect(*public.Status.PlayerStatuses[0].Wager).To(Equal(1))
+			for _, ps := range public.Status.PlayerStatuses {
+				Expect(ps.IsMe).To(BeFalse())
+			}
+			bytes, err := json.Marshal(public)
+			Expect(err).Should(Succeed())
+			Expect(string(bytes)).ToNot(ContainSubstring("MyCards"))
+			Expect(string(bytes)).ToNot(ContainSubstring("PlayerCards"))
+		})
 	})
 }
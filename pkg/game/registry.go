@@ -0,0 +1,75 @@
+package game
+
+import (
+	"sort"
+	"sync"
+)
+
+// DefaultGameID is the game that the legacy, unversioned endpoints act on
+const DefaultGameID = "default"
+
+// registeredGame is everything the servers keep per game
+type registeredGame struct {
+	ID                  string
+	Responder           Responder
+	idempotentResponses *idempotencyCache
+}
+
+// GameRegistry keeps track of the games being served, by id
+type GameRegistry struct {
+	mutex sync.RWMutex
+	games map[string]*registeredGame
+}
+
+func NewGameRegistry() *GameRegistry {
+	return &GameRegistry{games: map[string]*registeredGame{}}
+}
+
+// Add starts serving responder as the game id, replacing any game already using that id
+func (registry *GameRegistry) Add(id string, responder Responder) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	registry.games[id] = &registeredGame{
+		ID:                  id,
+		Responder:           responder,
+		idempotentResponses: newIdempotencyCache(idempotencyKeysPerPlayer, idempotencyPlayers),
+	}
+}
+
+func (registry *GameRegistry) Get(id string) (Responder, bool) {
+	game, ok := registry.game(id)
+	if !ok {
+		return nil, false
+	}
+	return game.Responder, true
+}
+
+// IDs returns the ids of every game, sorted
+func (registry *GameRegistry) IDs() []string {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+	ids := []string{}
+	for id := range registry.games {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func (registry *GameRegistry) game(id string) (*registeredGame, bool) {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+	game, ok := registry.games[id]
+	return game, ok
+}
+
+// all returns every game, sorted by id
+func (registry *GameRegistry) all() []*registeredGame {
+	games := []*registeredGame{}
+	for _, id := range registry.IDs() {
+		if game, ok := registry.game(id); ok {
+			games = append(games, game)
+		}
+	}
+	return games
+}
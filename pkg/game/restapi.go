@@ -0,0 +1,287 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// V1Request is what every v1 action's body has in common: who's acting, and the options for
+// applying the action.  See PlayerAction for what the options do.
+type V1Request struct {
+	Me              string
+	IdempotencyKey  string
+	ExpectedVersion *int
+}
+
+type V1WagerRequest struct {
+	V1Request
+	Hands int
+}
+
+type V1CardRequest struct {
+	V1Request
+	Card *Card
+}
+
+type V1CardsPerPlayerRequest struct {
+	V1Request
+	Count int
+}
+
+type V1DeckTypeRequest struct {
+	V1Request
+	DeckType DeckType
+}
+
+type V1GameList struct {
+	Games []string
+}
+
+// gameRoute handles one verb on one path beneath a game.  Path segments in braces, such as
+// {id}, match anything, and are passed to the handler by name; {id} is always the game.
+type gameRoute struct {
+	Method  string
+	Pattern string
+	Handle  func(w http.ResponseWriter, r *http.Request, game *registeredGame, params map[string]string)
+}
+
+var v1Routes = []*gameRoute{
+	{"GET", "{id}", v1GetGame},
+	{"POST", "{id}/players", v1Join},
+	{"GET", "{id}/players/{player}", v1GetPlayer},
+	{"DELETE", "{id}/players/{player}", v1RemovePlayer},
+	{"PUT", "{id}/cards-per-player", v1SetCardsPerPlayer},
+	{"PUT", "{id}/deck-type", v1SetDeckType},
+	{"GET", "{id}/rounds", v1GetRounds},
+	{"POST", "{id}/rounds", v1StartRound},
+	{"POST", "{id}/rounds/current/wagers", v1MakeWager},
+	{"POST", "{id}/rounds/current/cards", v1PlayCard},
+	{"POST", "{id}/rounds/current/finish", v1FinishRound},
+	{"POST", "{id}/finish", v1FinishGame},
+	{"GET", "{id}/archive", v1GetArchive},
+	{"GET", "{id}/archive/{guid}", v1GetArchivedGame},
+}
+
+// match returns the route's parameters if segments is a path it handles
+func (route *gameRoute) match(segments []string) (map[string]string, bool) {
+	pattern := strings.Split(route.Pattern, "/")
+	if len(pattern) != len(segments) {
+		return nil, false
+	}
+	params := map[string]string{}
+	for i, part := range pattern {
+		if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
+			params[part[1:len(part)-1]] = segments[i]
+		} else if part != segments[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+// setupV1Routes serves the resource-oriented api: each game is at /v1/games/{id}, with its
+// players, rounds and archive beneath it
+func setupV1Routes(mux *http.ServeMux, registry *GameRegistry) {
+	mux.HandleFunc("/v1/games", func(w http.ResponseWriter, r *http.Request) {
+		log.Debugf("receiving %s request to %s", r.Method, r.URL.String())
+		if r.Method != "GET" {
+			methodNotAllowed(w, r, []string{"GET"})
+			return
+		}
+		writeJson(w, &V1GameList{Games: registry.IDs()})
+	})
+
+	mux.HandleFunc("/v1/games/", func(w http.ResponseWriter, r *http.Request) {
+		log.Debugf("receiving %s request to %s", r.Method, r.URL.String())
+		serveGameRoutes(w, r, "/v1/games/", v1Routes, registry)
+	})
+}
+
+// serveGameRoutes finds the route for the part of r's path after prefix, looks up its game,
+// and hands the request over
+func serveGameRoutes(w http.ResponseWriter, r *http.Request, prefix string, routes []*gameRoute, registry *GameRegistry) {
+	segments := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, prefix), "/"), "/")
+	allowed := []string{}
+	for _, route := range routes {
+		params, ok := route.match(segments)
+		if !ok {
+			continue
+		}
+		if route.Method != r.Method {
+			allowed = append(allowed, route.Method)
+			continue
+		}
+		game, ok := registry.game(params["id"])
+		if !ok {
+			writeError(w, newGameError(ErrorCodeNotFound, nil, "game %s not found", params["id"]))
+			return
+		}
+		route.Handle(w, r, game, params)
+		return
+	}
+	if len(allowed) > 0 {
+		methodNotAllowed(w, r, allowed)
+		return
+	}
+	http.NotFound(w, r)
+}
+
+func methodNotAllowed(w http.ResponseWriter, r *http.Request, allowed []string) {
+	log.Errorf("verb %s not supported for %s", r.Method, r.URL.Path)
+	w.Header().Set(http.CanonicalHeaderKey("allow"), strings.Join(allowed, ", "))
+	http.Error(w, fmt.Sprintf("verb %s not supported", r.Method), http.StatusMethodNotAllowed)
+}
+
+// readBody validates a json request body against the schema named after body's type, and
+// decodes it into body; an empty body leaves it unchanged
+func readBody(r *http.Request, body interface{}) error {
+	bytes, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return newGameError(ErrorCodeInvalidRequest, nil, "unable to read body: %s", err.Error())
+	}
+	log.Debugf("received body %s", string(bytes))
+	if len(strings.TrimSpace(string(bytes))) == 0 {
+		return nil
+	}
+	if err := validateRequestBody(reflect.TypeOf(body).Elem().Name(), bytes); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(bytes, body); err != nil {
+		return newGameError(ErrorCodeInvalidRequest, nil, "unable to unmarshal json: %s", err.Error())
+	}
+	return nil
+}
+
+// v1Action reads a request body, and applies the action built from it just like /action would
+func v1Action(w http.ResponseWriter, r *http.Request, game *registeredGame, body interface{}, request *V1Request, build func(action *PlayerAction)) {
+	if err := readBody(r, body); err != nil {
+		log.Errorf("unable to read request: %+v", err)
+		writeError(w, err)
+		return
+	}
+	action := &PlayerAction{
+		Me:              request.Me,
+		IdempotencyKey:  request.IdempotencyKey,
+		ExpectedVersion: request.ExpectedVersion,
+	}
+	build(action)
+	serveAction(w, r, game, action)
+}
+
+func v1GetGame(w http.ResponseWriter, r *http.Request, game *registeredGame, params map[string]string) {
+	public, err := game.Responder.GetPublicModel()
+	if err != nil {
+		log.Errorf("unable to get public model: %+v", err)
+		writeError(w, err)
+		return
+	}
+	writeJson(w, public)
+}
+
+func v1Join(w http.ResponseWriter, r *http.Request, game *registeredGame, params map[string]string) {
+	request := &V1Request{}
+	v1Action(w, r, game, request, request, func(action *PlayerAction) {
+		action.Join = &JoinAction{}
+	})
+}
+
+func v1GetPlayer(w http.ResponseWriter, r *http.Request, game *registeredGame, params map[string]string) {
+	serveAction(w, r, game, &PlayerAction{Me: params["player"], GetModel: &GetPlayerModelAction{}})
+}
+
+func v1RemovePlayer(w http.ResponseWriter, r *http.Request, game *registeredGame, params map[string]string) {
+	request := &V1Request{}
+	v1Action(w, r, game, request, request, func(action *PlayerAction) {
+		action.RemovePlayer = &RemovePlayerAction{Player: params["player"]}
+	})
+}
+
+func v1SetCardsPerPlayer(w http.ResponseWriter, r *http.Request, game *registeredGame, params map[string]string) {
+	request := &V1CardsPerPlayerRequest{}
+	v1Action(w, r, game, request, &request.V1Request, func(action *PlayerAction) {
+		action.SetCardsPerPlayer = &SetCardsPerPlayerAction{Count: request.Count}
+	})
+}
+
+func v1SetDeckType(w http.ResponseWriter, r *http.Request, game *registeredGame, params map[string]string) {
+	request := &V1DeckTypeRequest{}
+	v1Action(w, r, game, request, &request.V1Request, func(action *PlayerAction) {
+		action.SetDeckType = &SetDeckTypePlayerAction{DeckType: request.DeckType}
+	})
+}
+
+func v1GetRounds(w http.ResponseWriter, r *http.Request, game *registeredGame, params map[string]string) {
+	records, err := game.Responder.GetFinishedRounds()
+	if err != nil {
+		log.Errorf("unable to get finished rounds: %+v", err)
+		writeError(w, err)
+		return
+	}
+	writeJson(w, records)
+}
+
+func v1StartRound(w http.ResponseWriter, r *http.Request, game *registeredGame, params map[string]string) {
+	request := &V1Request{}
+	v1Action(w, r, game, request, request, func(action *PlayerAction) {
+		action.StartRound = &StartRoundAction{}
+	})
+}
+
+func v1MakeWager(w http.ResponseWriter, r *http.Request, game *registeredGame, params map[string]string) {
+	request := &V1WagerRequest{}
+	v1Action(w, r, game, request, &request.V1Request, func(action *PlayerAction) {
+		action.MakeWager = &MakeWagerAction{Hands: request.Hands}
+	})
+}
+
+func v1PlayCard(w http.ResponseWriter, r *http.Request, game *registeredGame, params map[string]string) {
+	request := &V1CardRequest{}
+	if err := readBody(r, request); err != nil {
+		log.Errorf("unable to read request: %+v", err)
+		writeError(w, err)
+		return
+	}
+	if request.Card == nil {
+		writeError(w, newGameError(ErrorCodeInvalidCard, nil, "missing Card"))
+		return
+	}
+	serveAction(w, r, game, &PlayerAction{
+		Me:              request.Me,
+		IdempotencyKey:  request.IdempotencyKey,
+		ExpectedVersion: request.ExpectedVersion,
+		PlayCard:        request.Card,
+	})
+}
+
+func v1FinishRound(w http.ResponseWriter, r *http.Request, game *registeredGame, params map[string]string) {
+	request := &V1Request{}
+	v1Action(w, r, game, request, request, func(action *PlayerAction) {
+		action.FinishRound = &FinishRoundAction{}
+	})
+}
+
+func v1FinishGame(w http.ResponseWriter, r *http.Request, game *registeredGame, params map[string]string) {
+	request := &V1Request{}
+	v1Action(w, r, game, request, request, func(action *PlayerAction) {
+		action.FinishGame = &FinishGameAction{}
+	})
+}
+
+func v1GetArchive(w http.ResponseWriter, r *http.Request, game *registeredGame, params map[string]string) {
+	serveArchivePage(w, r, game.Responder)
+}
+
+func v1GetArchivedGame(w http.ResponseWriter, r *http.Request, game *registeredGame, params map[string]string) {
+	record, err := game.Responder.GetArchivedGame(params["guid"])
+	if err != nil {
+		log.Errorf("unable to get archived game: %+v", err)
+		writeError(w, err)
+		return
+	}
+	writeJson(w, record)
+}
@@ -0,0 +1,105 @@
+package game
+
+import (
+	"context"
+	"encoding/json"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// v1Call sends a request to the v1 api, and decodes a successful response into result
+func v1Call(client *Client, method string, path string, body interface{}, result interface{}) (int, error) {
+	url := client.url("v1/" + path)
+	req := client.Resty.R().SetHeader("Content-Type", "application/json")
+	if body != nil {
+		req = req.SetBody(body)
+	}
+	resp, err := req.Execute(method, url)
+	Expect(err).Should(Succeed())
+	if err := responseError(url, resp); err != nil {
+		return resp.StatusCode(), err
+	}
+	if result != nil {
+		Expect(json.Unmarshal(resp.Body(), result)).Should(Succeed())
+	}
+	return resp.StatusCode(), nil
+}
+
+func RunRestApiTests() {
+	Describe("v1 api", func() {
+		ctx := context.Background()
+
+		It("should play a round through the game's resources", func() {
+			_, client, stop := newTestServer()
+			defer stop()
+
+			games := &V1GameList{}
+			_, err := v1Call(client, "GET", "games", nil, games)
+			Expect(err).Should(Succeed())
+			Expect(games.Games).To(Equal([]string{DefaultGameID}))
+
+			pm := &PlayerModel{}
+			for _, player := range []string{"abc", "def"} {
+				_, err = v1Call(client, "POST", "games/default/players", &V1Request{Me: player}, pm)
+				Expect(err).Should(Succeed())
+			}
+			_, err = v1Call(client, "PUT", "games/default/deck-type", &V1DeckTypeRequest{V1Request: V1Request{Me: "abc"}, DeckType: DeckTypeStandard}, pm)
+			Expect(err).Should(Succeed())
+			_, err = v1Call(client, "PUT", "games/default/cards-per-player", &V1CardsPerPlayerRequest{V1Request: V1Request{Me: "abc"}, Count: 1}, pm)
+			Expect(err).Should(Succeed())
+			Expect(pm.Game.CardsPerPlayer).To(Equal(1))
+
+			_, err = v1Call(client, "POST", "games/default/rounds", &V1Request{Me: "abc"}, pm)
+			Expect(err).Should(Succeed())
+			Expect(pm.State).To(Equal(PlayerStateWagerTurn))
+			stale := pm.Version
+			for _, player := range []string{"abc", "def"} {
+				_, err = v1Call(client, "POST", "games/default/rounds/current/wagers", &V1WagerRequest{V1Request: V1Request{Me: player}, Hands: 0}, pm)
+				Expect(err).Should(Succeed())
+			}
+			_, err = v1Call(client, "POST", "games/default/rounds/current/wagers", &V1WagerRequest{V1Request: V1Request{Me: "abc", ExpectedVersion: &stale}, Hands: 0}, nil)
+			Expect(AsGameError(err).Code).To(Equal(ErrorCodeVersionConflict))
+
+			for _, player := range []string{"abc", "def"} {
+				_, err = v1Call(client, "GET", "games/default/players/"+player, nil, pm)
+				Expect(err).Should(Succeed())
+				body := map[string]interface{}{"Me": player, "Card": pm.LegalCards[0].String()}
+				_, err = v1Call(client, "POST", "games/default/rounds/current/cards", body, pm)
+				Expect(err).Should(Succeed())
+			}
+			Expect(pm.State).To(Equal(PlayerStateRoundFinished))
+			_, err = v1Call(client, "POST", "games/default/rounds/current/finish", &V1Request{Me: "abc"}, pm)
+			Expect(err).Should(Succeed())
+
+			rounds := []*RoundRecord{}
+			_, err = v1Call(client, "GET", "games/default/rounds", nil, &rounds)
+			Expect(err).Should(Succeed())
+			Expect(rounds).To(HaveLen(1))
+
+			_, err = v1Call(client, "DELETE", "games/default/players/def", &V1Request{Me: "abc"}, pm)
+			Expect(err).Should(Succeed())
+			Expect(pm.Game.Players).To(Equal([]string{"abc"}))
+		})
+
+		It("should reject unknown games, routes and verbs", func() {
+			_, client, stop := newTestServer()
+			defer stop()
+			_, err := v1Call(client, "GET", "games/nope/players/abc", nil, nil)
+			Expect(AsGameError(err).Code).To(Equal(ErrorCodeNotFound))
+			status, _ := v1Call(client, "GET", "games/default/rounds/current/nope", nil, nil)
+			Expect(status).To(Equal(404))
+			status, _ = v1Call(client, "GET", "games/default/rounds/current/wagers", nil, nil)
+			Expect(status).To(Equal(405))
+			_, err = v1Call(client, "POST", "games/default/rounds/current/cards", &V1Request{Me: "abc"}, nil)
+			Expect(AsGameError(err).Code).To(Equal(ErrorCodeInvalidRequest))
+		})
+
+		It("should list every action in the legacy endpoint's error", func() {
+			_, client, stop := newTestServer()
+			defer stop()
+			_, err := client.postJson(ctx, "action", &PlayerAction{Me: "abc"}, nil)
+			Expect(AsGameError(err).Message).To(ContainSubstring("SetDeckType"))
+			Expect(AsGameError(err).Message).To(ContainSubstring("FinishRound"))
+		})
+	})
+}
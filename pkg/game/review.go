@@ -0,0 +1,32 @@
+package game
+
+// Trick is a completed hand: the suit that was led, the cards in the order they were
+// played, and who won.
+type Trick struct {
+	Suit  string
+	Cards []*CardPlay
+	// Notation is the cards, in order, in short notation -- "QH 10H AS"
+	Notation string
+	Winner   string
+}
+
+func newTrick(hand *Hand) *Trick {
+	cards := []*Card{}
+	for _, play := range hand.Plays {
+		cards = append(cards, play.Card)
+	}
+	return &Trick{
+		Suit:     hand.Suit,
+		Cards:    append([]*CardPlay{}, hand.Plays...),
+		Notation: FormatCards(hand.Deck, cards),
+		Winner:   hand.Leader,
+	}
+}
+
+func newTricks(round *Round) []*Trick {
+	tricks := []*Trick{}
+	for _, hand := range round.FinishedHands {
+		tricks = append(tricks, newTrick(hand))
+	}
+	return tricks
+}
@@ -1,8 +1,10 @@
 package game
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/pkg/errors"
+	"sort"
 )
 
 type RoundState int
@@ -34,6 +36,41 @@ func (r RoundState) MarshalText() (text []byte, err error) {
 	return []byte(r.String()), nil
 }
 
+func parseRoundState(text string) (RoundState, error) {
+	switch text {
+	case "RoundStateWagers":
+		return RoundStateWagers, nil
+	case "RoundStateHandInProgress":
+		return RoundStateHandInProgress, nil
+	case "RoundStateFinished":
+		return RoundStateFinished, nil
+	}
+	return RoundStateWagers, errors.New(fmt.Sprintf("unable to parse round state %s", text))
+}
+
+func (r *RoundState) UnmarshalJSON(data []byte) error {
+	var str string
+	err := json.Unmarshal(data, &str)
+	if err != nil {
+		return err
+	}
+	state, err := parseRoundState(str)
+	if err != nil {
+		return err
+	}
+	*r = state
+	return nil
+}
+
+func (r *RoundState) UnmarshalText(text []byte) (err error) {
+	state, err := parseRoundState(string(text))
+	if err != nil {
+		return err
+	}
+	*r = state
+	return nil
+}
+
 type PlayerCard struct {
 	Card  *Card
 	Count int
@@ -65,7 +102,7 @@ func (cb *CardBag) add(card *Card) {
 func (cb *CardBag) remove(card *Card) error {
 	key := card.Key()
 	if _, ok := cb.Cards[key]; !ok {
-		return errors.New(fmt.Sprintf("can't remove card %+v, not found", card))
+		return errors.New(fmt.Sprintf("can't remove card %s, not found", card))
 	}
 	cb.Cards[key].Count--
 	if cb.Cards[key].Count == 0 {
@@ -143,27 +180,47 @@ func (round *Round) deal() {
 	round.TrumpSuit = RandomSuit(round.Deck)
 }
 
-func (round *Round) Wager(player string, hands int) error {
+// validateWager checks whether player may wager hands right now, without changing anything
+func (round *Round) validateWager(player string, hands int) error {
 	if round.State != RoundStateWagers {
-		return errors.New(fmt.Sprintf("expected state RoundStateWagers for wager, found %s", round.State.String()))
+		return newGameError(ErrorCodeWrongRoundState, map[string]interface{}{"State": round.State}, "expected state RoundStateWagers for wager, found %s", round.State.String())
 	}
-	if hands > round.CardsPerPlayer {
-		return errors.New(fmt.Sprintf("%d cards per player, but wager was %d", round.CardsPerPlayer, hands))
+	if hands < 0 || hands > round.CardsPerPlayer {
+		return newGameError(ErrorCodeInvalidWager, map[string]interface{}{"Hands": hands, "CardsPerPlayer": round.CardsPerPlayer}, "%d cards per player, but wager was %d", round.CardsPerPlayer, hands)
 	}
 	// players must make wagers in order
 	nextPlayer := round.PlayersOrder[len(round.Wagers)]
 	if nextPlayer != player {
-		return errors.New(fmt.Sprintf("it is player %s's turn to wager, but got %s", nextPlayer, player))
-	}
-	playerCount, wagerCount := len(round.PlayersOrder), len(round.Wagers)
-	// on the last (i.e. dealer) wager?
-	if playerCount == wagerCount+1 {
-		// then can't add up to the number of cards
-		if hands+round.WagerSum == round.CardsPerPlayer {
-			// TODO distinguish between violations of game rules (like this) and something else unexpected going
-			// wrong -- like above, where a player has already made a wager or where a player is unrecognized
-			return errors.New(fmt.Sprintf("dealer's wager can't add up to %d (had %d already, wagered %d)", round.CardsPerPlayer, round.WagerSum, hands))
+		return newGameError(ErrorCodeNotYourTurn, map[string]interface{}{"Expected": nextPlayer, "Player": player}, "it is player %s's turn to wager, but got %s", nextPlayer, player)
+	}
+	// on the last (i.e. dealer) wager?  then can't add up to the number of cards
+	if round.isDealerWager() && hands+round.WagerSum == round.CardsPerPlayer {
+		return newGameError(ErrorCodeDealerWagerRestriction, map[string]interface{}{"Forbidden": round.CardsPerPlayer - round.WagerSum}, "dealer's wager can't add up to %d (had %d already, wagered %d)", round.CardsPerPlayer, round.WagerSum, hands)
+	}
+	return nil
+}
+
+func (round *Round) isDealerWager() bool {
+	return len(round.PlayersOrder) == len(round.Wagers)+1
+}
+
+// legalWagers lists every wager player could make right now -- which is nothing, if it's not their turn
+func (round *Round) legalWagers(player string) []int {
+	wagers := []int{}
+	for hands := 0; hands <= round.CardsPerPlayer; hands++ {
+		if round.validateWager(player, hands) == nil {
+			wagers = append(wagers, hands)
 		}
+	}
+	return wagers
+}
+
+func (round *Round) Wager(player string, hands int) error {
+	err := round.validateWager(player, hands)
+	if err != nil {
+		return err
+	}
+	if round.isDealerWager() {
 		round.startHand()
 	}
 	round.Wagers[player] = hands
@@ -194,23 +251,24 @@ func (round *Round) startHand() {
 	round.CurrentHand = NewHand(round.Deck, round.TrumpSuit, players)
 }
 
-func (round *Round) PlayCard(player string, card *Card) error {
+// validatePlayCard checks whether player may play card right now, without changing anything
+func (round *Round) validatePlayCard(player string, card *Card) error {
 	if round.State != RoundStateHandInProgress {
-		return errors.New(fmt.Sprintf("expected state RoundStateHandInProgress, found %s", round.State.String()))
+		return newGameError(ErrorCodeWrongRoundState, map[string]interface{}{"State": round.State}, "expected state RoundStateHandInProgress, found %s", round.State.String())
 	}
 
 	// is this the right next player?
 	hand := round.CurrentHand
-	nextPlayer := hand.PlayersOrder[len(hand.CardsPlayed)]
+	nextPlayer := hand.PlayersOrder[len(hand.Plays)]
 	if nextPlayer != player {
-		return errors.New(fmt.Sprintf("expected player %s, got %s", nextPlayer, player))
+		return newGameError(ErrorCodeNotYourTurn, map[string]interface{}{"Expected": nextPlayer, "Player": player}, "expected player %s, got %s", nextPlayer, player)
 	}
 	// is this a card they have?
 	if !round.PlayerCards[player].has(card) {
-		return errors.New(fmt.Sprintf("player %s can't play card %+v: does not have it", player, card))
+		return newGameError(ErrorCodeCardNotInHand, map[string]interface{}{"Card": card}, "player %s can't play card %s: does not have it", player, card)
 	}
 	//is this a card they can legally play?
-	if len(hand.CardsPlayed) > 0 {
+	if len(hand.Plays) > 0 {
 		// must follow suit if possible, otherwise anything goes
 		mustFollowSuit := false
 		for _, card := range round.PlayerCards[player].cards() {
@@ -220,23 +278,61 @@ func (round *Round) PlayCard(player string, card *Card) error {
 			}
 		}
 		if mustFollowSuit && card.Suit != hand.Suit {
-			return errors.New(fmt.Sprintf("player %s must follow suit %s, but did not", player, hand.Suit))
+			return newGameError(ErrorCodeMustFollowSuit, map[string]interface{}{"Suit": hand.Suit}, "player %s must follow suit %s, but did not", player, hand.Suit)
+		}
+	}
+	return nil
+}
+
+// legalCards lists every card player could play right now -- which is nothing, if it's not their turn.
+// Duplicate cards are only listed once.
+func (round *Round) legalCards(player string) []*Card {
+	cards := []*Card{}
+	cardBag, ok := round.PlayerCards[player]
+	if !ok {
+		return cards
+	}
+	for _, pc := range cardBag.Cards {
+		if round.validatePlayCard(player, pc.Card) == nil {
+			cards = append(cards, pc.Card)
 		}
 	}
+	sort.Slice(cards, func(i, j int) bool {
+		return round.Deck.Compare(cards[i], cards[j]) < 0
+	})
+	return cards
+}
+
+func (round *Round) PlayCard(player string, card *Card) error {
+	err := round.validatePlayCard(player, card)
+	if err != nil {
+		return err
+	}
+	hand := round.CurrentHand
 	hand.PlayCard(player, card)
-	err := round.PlayerCards[player].remove(card)
+	err = round.PlayerCards[player].remove(card)
 	if err != nil {
 		return errors.WithMessagef(err, "unable to remove card")
 	}
 
 	// have we finished the hand?
-	if len(hand.CardsPlayed) == len(round.PlayersOrder) {
+	if len(hand.Plays) == len(round.PlayersOrder) {
 		round.finishHand()
 	}
 
 	return nil
 }
 
+// HandsWon counts the hands won so far by each player; players who haven't won
+// any hands are not present.
+func (round *Round) HandsWon() map[string]int {
+	playerWins := map[string]int{}
+	for _, hand := range round.FinishedHands {
+		playerWins[hand.Leader]++
+	}
+	return playerWins
+}
+
 func (round *Round) finishHand() {
 	round.FinishedHands = append(round.FinishedHands, round.CurrentHand)
 	round.CurrentHand = nil
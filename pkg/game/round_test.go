@@ -260,5 +260,70 @@ func RunRoundTests() {
 				Expect(round.State).To(Equal(RoundStateFinished))
 			})
 		})
+
+		Describe("Legal moves", func() {
+			It("Only lists wagers for the next wagerer, and excludes the dealer's forbidden wager", func() {
+				round := NewRound(players, deck, 3)
+
+				Expect(round.legalWagers("jimbo")).To(BeEmpty())
+				Expect(round.legalWagers("player1")).To(Equal([]int{0, 1, 2, 3}))
+				Expect(round.Wager("player1", 1)).Should(Succeed())
+				Expect(round.Wager("jimbo", 1)).Should(Succeed())
+				Expect(round.legalWagers("alfonso")).To(Equal([]int{0, 2, 3}))
+				Expect(round.Wager("alfonso", 0)).Should(Succeed())
+
+				Expect(round.legalWagers("alfonso")).To(BeEmpty())
+			})
+
+			It("Only lists cards for the next player, who must follow suit if possible", func() {
+				round := wageredRound()
+
+				Expect(round.legalCards("jimbo")).To(BeEmpty())
+				Expect(round.legalCards("player1")).To(HaveLen(17))
+				Expect(round.PlayCard("player1", twoOfClubs)).Should(Succeed())
+
+				legalCards := round.legalCards("jimbo")
+				Expect(legalCards).ToNot(BeEmpty())
+				for _, card := range legalCards {
+					Expect(card.Suit).To(Equal("Clubs"))
+					Expect(round.validatePlayCard("jimbo", card)).Should(Succeed())
+				}
+				Expect(legalCards).To(ContainElement(threeOfClubs))
+				Expect(legalCards).ToNot(ContainElement(twoOfDiamonds))
+			})
+		})
+
+		Describe("Review", func() {
+			It("Lists every finished trick, with cards in the order they were played", func() {
+				round := smallWageredRound()
+
+				Expect(round.PlayCard("player1", twoOfClubs)).Should(Succeed())
+				Expect(round.PlayCard("jimbo", threeOfClubs)).Should(Succeed())
+				Expect(newTricks(round)).To(BeEmpty())
+				Expect(round.PlayCard("alfonso", fourOfClubs)).Should(Succeed())
+
+				Expect(round.PlayCard("alfonso", sevenOfClubs)).Should(Succeed())
+				Expect(round.PlayCard("player1", fiveOfClubs)).Should(Succeed())
+				Expect(round.PlayCard("jimbo", sixOfClubs)).Should(Succeed())
+
+				tricks := newTricks(round)
+				Expect(tricks).To(HaveLen(2))
+				for i, expected := range [][]*Card{
+					{twoOfClubs, threeOfClubs, fourOfClubs},
+					{sevenOfClubs, fiveOfClubs, sixOfClubs},
+				} {
+					Expect(tricks[i].Suit).To(Equal("Clubs"))
+					Expect(tricks[i].Winner).To(Equal("alfonso"))
+					cards := []*Card{}
+					for _, play := range tricks[i].Cards {
+						cards = append(cards, play.Card)
+					}
+					Expect(cards).To(Equal(expected))
+				}
+				Expect(tricks[0].Notation).To(Equal("2C 3C 4C"))
+				Expect(tricks[1].Cards[0].Player).To(Equal("alfonso"))
+				Expect(tricks[1].Cards[0].Time.Before(tricks[0].Cards[2].Time)).To(BeFalse())
+			})
+		})
 	})
 }
@@ -1,25 +1,47 @@
 package game
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	log "github.com/sirupsen/logrus"
+	"io/fs"
 	"io/ioutil"
+	"net"
 	"net/http"
+	"os"
+	"strconv"
+	"time"
 )
 
 type Responder interface {
-	GetModel() string
-	GetPlayerModel(player string) *PlayerModel
-	Join(player string) (string, error)
-	RemovePlayer(player string) error
-	SetCardsPerPlayer(count int) error
-	SetDeckType(deckType DeckType) error
-	StartRound() error
-	MakeWager(player string, hands int) error
-	PlayCard(player string, card *Card) error
-	FinishRound() error
+	GetModel() (string, error)
+	GetPublicModel() (*PublicModel, error)
+	GetPlayerModel(player string) (*PlayerModel, error)
+	Join(ctx context.Context, player string) (string, error)
+	RemovePlayer(ctx context.Context, player string) error
+	SetCardsPerPlayer(ctx context.Context, count int) error
+	SetDeckType(ctx context.Context, deckType DeckType) error
+	StartRound(ctx context.Context) error
+	MakeWager(ctx context.Context, player string, hands int) error
+	PlayCard(ctx context.Context, player string, card *Card) error
+	FinishRound(ctx context.Context) error
+	FinishGame(ctx context.Context) error
+	GetFinishedRounds() ([]*RoundRecord, error)
+	GetArchive(offset int, limit int) (*ArchivePage, error)
+	GetArchivedGame(guid string) (*GameRecord, error)
+	Changed() <-chan struct{}
+	// Act applies a player's action, honoring its ExpectedVersion, and returns the player
+	// whose model should be sent back
+	Act(ctx context.Context, action *PlayerAction) (string, error)
+	// Ping checks that the game is still processing actions
+	Ping(ctx context.Context) error
+	// admin actions
+	ForceFinishRound(ctx context.Context) error
+	ForceRemovePlayer(ctx context.Context, player string) error
+	Reset(ctx context.Context) error
+	Announce(ctx context.Context, message string) error
 }
 
 type GetPlayerModelAction struct{}
@@ -46,8 +68,16 @@ type StartRoundAction struct{}
 
 type FinishRoundAction struct{}
 
+type FinishGameAction struct{}
+
 type PlayerAction struct {
-	Me                string
+	Me string
+	// IdempotencyKey, if set, makes retries safe: an action is applied at most once for each
+	// of a player's recent keys, and repeats get the original response
+	IdempotencyKey string
+	// ExpectedVersion, if set, is the version of the game the action was based on; if the game
+	// has changed since, the action is rejected
+	ExpectedVersion   *int
 	GetModel          *GetPlayerModelAction
 	Join              *JoinAction
 	MakeWager         *MakeWagerAction
@@ -57,13 +87,148 @@ type PlayerAction struct {
 	SetDeckType       *SetDeckTypePlayerAction
 	StartRound        *StartRoundAction
 	FinishRound       *FinishRoundAction
+	FinishGame        *FinishGameAction
+}
+
+const (
+	defaultArchivePageSize = 20
+	// actionTimeout bounds how long a request waits for its action to be processed
+	actionTimeout = 10 * time.Second
+)
+
+// writeError sends err to the client as json, with the status code for its ErrorCode
+func writeError(w http.ResponseWriter, err error) {
+	gameError := AsGameError(err)
+	bytes, marshalErr := json.MarshalIndent(gameError, "", "  ")
+	if marshalErr != nil {
+		log.Errorf("unable to serialize error %+v: %+v", gameError, marshalErr)
+		http.Error(w, gameError.Message, gameError.Code.HTTPStatus())
+		return
+	}
+	header := w.Header()
+	header.Set(http.CanonicalHeaderKey("content-type"), "application/json")
+	header.Set(http.CanonicalHeaderKey("x-content-type-options"), "nosniff")
+	w.WriteHeader(gameError.Code.HTTPStatus())
+	fmt.Fprint(w, string(bytes))
 }
 
-func SetupHTTPServer(uiDirectory string, responder Responder) {
-	http.Handle("/", http.FileServer(http.Dir(uiDirectory)))
-	http.Handle("/metrics", promhttp.Handler())
+func writeJson(w http.ResponseWriter, obj interface{}) {
+	bytes, err := json.MarshalIndent(obj, "", "  ")
+	if err != nil {
+		log.Errorf("unable to serialize json: %+v", err)
+		writeError(w, err)
+		return
+	}
+	w.Header().Set(http.CanonicalHeaderKey("content-type"), "application/json")
+	fmt.Fprint(w, string(bytes))
+}
 
-	http.HandleFunc("/model", func(w http.ResponseWriter, r *http.Request) {
+func intQueryParam(r *http.Request, name string, defaultValue int) (int, error) {
+	values, ok := r.URL.Query()[name]
+	if !ok || len(values) == 0 {
+		return defaultValue, nil
+	}
+	return strconv.Atoi(values[0])
+}
+
+// serveArchivePage writes the page of the archive picked by the offset and limit query parameters
+func serveArchivePage(w http.ResponseWriter, r *http.Request, responder Responder) {
+	offset, err := intQueryParam(r, "offset", 0)
+	if err != nil {
+		writeError(w, newGameError(ErrorCodeInvalidRequest, nil, "invalid offset: %s", err.Error()))
+		return
+	}
+	limit, err := intQueryParam(r, "limit", defaultArchivePageSize)
+	if err != nil {
+		writeError(w, newGameError(ErrorCodeInvalidRequest, nil, "invalid limit: %s", err.Error()))
+		return
+	}
+	page, err := responder.GetArchive(offset, limit)
+	if err != nil {
+		log.Errorf("unable to get archive: %+v", err)
+		writeError(w, err)
+		return
+	}
+	writeJson(w, page)
+}
+
+// Server serves the games in its registry, and the ui, over http.  It has its own mux, so any
+// number of servers can run in one process.
+type Server struct {
+	Config   *Config
+	Registry *GameRegistry
+	// UI is served unless Config.UIDirectory overrides it
+	UI   fs.FS
+	mux  *http.ServeMux
+	http *http.Server
+}
+
+// NewServer sets up a server for the games in registry, and ui.  Config.AdminToken guards
+// admin-only endpoints; if it's empty, they're turned off.  Until the default game has been
+// added to registry, the unversioned endpoints respond with ErrNotReady.
+func NewServer(config *Config, registry *GameRegistry, ui fs.FS) *Server {
+	server := &Server{Config: config, Registry: registry, UI: ui, mux: http.NewServeMux()}
+	server.http = &http.Server{Handler: server.mux}
+	server.setupRoutes()
+	return server
+}
+
+// Handler serves every endpoint, for mounting the server elsewhere or testing it with httptest
+func (server *Server) Handler() http.Handler {
+	return server.mux
+}
+
+// Serve serves http on listener until Shutdown is called
+func (server *Server) Serve(listener net.Listener) error {
+	err := server.http.Serve(listener)
+	if err == http.ErrServerClosed {
+		return nil
+	}
+	return err
+}
+
+// Shutdown stops accepting requests, and waits for in-flight requests -- and therefore their
+// actions -- to finish
+func (server *Server) Shutdown(ctx context.Context) error {
+	return server.http.Shutdown(ctx)
+}
+
+// ui is the ui to serve: the UIDirectory, if one's configured -- handy for working on the ui
+// without rebuilding -- or else the UI the server was given
+func (server *Server) ui() fs.FS {
+	if server.Config.UIDirectory != "" {
+		return os.DirFS(server.Config.UIDirectory)
+	}
+	return server.UI
+}
+
+func (server *Server) setupRoutes() {
+	mux := server.mux
+	registry := server.Registry
+	adminToken := server.Config.AdminToken
+
+	// withDefaultGame hands the unversioned endpoints the game they act on
+	withDefaultGame := func(handle func(w http.ResponseWriter, r *http.Request, game *registeredGame)) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			game, ok := registry.game(DefaultGameID)
+			if !ok {
+				writeError(w, ErrNotReady)
+				return
+			}
+			handle(w, r, game)
+		}
+	}
+
+	if ui := server.ui(); ui != nil {
+		mux.Handle("/", http.FileServer(http.FS(ui)))
+	}
+	mux.Handle("/metrics", promhttp.Handler())
+	setupV1Routes(mux, registry)
+	setupAdminRoutes(mux, registry, adminToken)
+	setupOpenAPIRoute(mux)
+	setupHealthRoutes(mux, registry)
+
+	mux.HandleFunc("/model", withDefaultGame(func(w http.ResponseWriter, r *http.Request, game *registeredGame) {
 		log.Debugf("receiving %s request to %s", r.Method, r.URL.String())
 		if r.Method == "GET" {
 			var response string
@@ -71,17 +236,51 @@ func SetupHTTPServer(uiDirectory string, responder Responder) {
 			urlParams := r.URL.Query()
 			if players, ok := urlParams["player"]; len(players) > 0 && ok {
 				player := players[0]
-				pm := responder.GetPlayerModel(player)
+				var pm *PlayerModel
+				pm, err = game.Responder.GetPlayerModel(player)
+				if err != nil {
+					log.Errorf("unable to get player model: %+v", err)
+					writeError(w, err)
+					return
+				}
 				var pmBytes []byte
 				pmBytes, err = json.MarshalIndent(pm, "", "  ")
 				if err != nil {
 					log.Errorf("unable to serialize json: %+v", err)
-					http.Error(w, err.Error(), 500)
+					writeError(w, err)
 					return
 				}
 				response = string(pmBytes)
+			} else if hasCredential(r) {
+				// the whole game -- every player's cards included -- is only for admins
+				err = checkAdmin(r, adminToken)
+				if err != nil {
+					log.Errorf("rejecting request for full model from %s: %+v", r.RemoteAddr, err)
+					writeError(w, err)
+					return
+				}
+				response, err = game.Responder.GetModel()
+				if err != nil {
+					log.Errorf("unable to get model: %+v", err)
+					writeError(w, err)
+					return
+				}
 			} else {
-				response = responder.GetModel()
+				var public *PublicModel
+				public, err = game.Responder.GetPublicModel()
+				if err != nil {
+					log.Errorf("unable to get public model: %+v", err)
+					writeError(w, err)
+					return
+				}
+				var publicBytes []byte
+				publicBytes, err = json.MarshalIndent(public, "", "  ")
+				if err != nil {
+					log.Errorf("unable to serialize json: %+v", err)
+					writeError(w, err)
+					return
+				}
+				response = string(publicBytes)
 			}
 			w.Header().Set(http.CanonicalHeaderKey("content-type"), "application/json")
 			fmt.Fprint(w, response)
@@ -89,74 +288,151 @@ func SetupHTTPServer(uiDirectory string, responder Responder) {
 			log.Errorf("verb %s not supported for /model", r.Method)
 			http.NotFound(w, r)
 		}
-	})
+	}))
 
-	http.HandleFunc("/action", func(w http.ResponseWriter, r *http.Request) {
+	mux.HandleFunc("/rounds", withDefaultGame(func(w http.ResponseWriter, r *http.Request, game *registeredGame) {
+		log.Debugf("receiving %s request to %s", r.Method, r.URL.String())
+		if r.Method != "GET" {
+			log.Errorf("verb %s not supported for /rounds", r.Method)
+			http.NotFound(w, r)
+			return
+		}
+		records, err := game.Responder.GetFinishedRounds()
+		if err != nil {
+			log.Errorf("unable to get finished rounds: %+v", err)
+			writeError(w, err)
+			return
+		}
+		writeJson(w, records)
+	}))
+
+	mux.HandleFunc("/archive", withDefaultGame(func(w http.ResponseWriter, r *http.Request, game *registeredGame) {
+		log.Debugf("receiving %s request to %s", r.Method, r.URL.String())
+		if r.Method != "GET" {
+			log.Errorf("verb %s not supported for /archive", r.Method)
+			http.NotFound(w, r)
+			return
+		}
+		serveArchivePage(w, r, game.Responder)
+	}))
+
+	mux.HandleFunc("/archive/game", withDefaultGame(func(w http.ResponseWriter, r *http.Request, game *registeredGame) {
+		log.Debugf("receiving %s request to %s", r.Method, r.URL.String())
+		if r.Method != "GET" {
+			log.Errorf("verb %s not supported for /archive/game", r.Method)
+			http.NotFound(w, r)
+			return
+		}
+		guid := r.URL.Query().Get("id")
+		if guid == "" {
+			writeError(w, newGameError(ErrorCodeInvalidRequest, nil, "missing query parameter id"))
+			return
+		}
+		record, err := game.Responder.GetArchivedGame(guid)
+		if err != nil {
+			log.Errorf("unable to get archived game: %+v", err)
+			writeError(w, err)
+			return
+		}
+		writeJson(w, record)
+	}))
+
+	mux.HandleFunc("/action", withDefaultGame(func(w http.ResponseWriter, r *http.Request, game *registeredGame) {
 		log.Debugf("receiving %s request to %s", r.Method, r.URL.String())
 		if r.Method == "POST" {
 			body, err := ioutil.ReadAll(r.Body)
 			log.Debugf("received body %s", string(body))
 			if err != nil {
 				log.Errorf("unable to read body: %+v", err)
-				http.Error(w, err.Error(), 400)
+				writeError(w, newGameError(ErrorCodeInvalidRequest, nil, "unable to read body: %s", err.Error()))
 				return
 			}
 			log.Debugf("received POST to /action with body %s", body)
-			var action PlayerAction
-			err = json.Unmarshal(body, &action)
+			err = validateRequestBodyTypes("PlayerAction", body)
 			if err != nil {
-				log.Errorf("unable to unmarshal json: %+v", err)
-				http.Error(w, err.Error(), 400)
+				log.Errorf("invalid request: %+v", err)
+				writeError(w, err)
 				return
 			}
-
-			var actionErr error
-			var player string = action.Me
-			if action.GetModel != nil {
-				actionErr = nil // nothing else to do!
-				// just let the playerModel be grabbed down below
-			} else if action.Join != nil {
-				player, actionErr = responder.Join(action.Me)
-			} else if action.RemovePlayer != nil {
-				actionErr = responder.RemovePlayer(action.RemovePlayer.Player)
-			} else if action.SetCardsPerPlayer != nil {
-				actionErr = responder.SetCardsPerPlayer(action.SetCardsPerPlayer.Count)
-			} else if action.SetDeckType != nil {
-				actionErr = responder.SetDeckType(action.SetDeckType.DeckType)
-			} else if action.StartRound != nil {
-				actionErr = responder.StartRound()
-			} else if action.MakeWager != nil {
-				actionErr = responder.MakeWager(action.Me, action.MakeWager.Hands)
-			} else if action.PlayCard != nil {
-				actionErr = responder.PlayCard(action.Me, &Card{Suit: action.PlayCard.Suit, Number: action.PlayCard.Number})
-			} else if action.FinishRound != nil {
-				actionErr = responder.FinishRound()
-			} else {
-				http.Error(w, "action must have non-nil for one of GetModel, Join, StartRound, MakeWager, RemovePlayer, SetCardsPerPlayer, or PlayCard", 400)
-				return
-			}
-			if actionErr != nil {
-				log.Errorf("unable to execute action: %+v", actionErr)
-				http.Error(w, actionErr.Error(), 400)
-				return
-			}
-
-			pm := responder.GetPlayerModel(player)
-			pmBytes, err := json.MarshalIndent(pm, "", "  ")
+			var action PlayerAction
+			err = json.Unmarshal(body, &action)
 			if err != nil {
-				log.Errorf("unable to serialize json: %+v", err)
-				http.Error(w, err.Error(), 500)
+				log.Errorf("unable to unmarshal json: %+v", err)
+				writeError(w, newGameError(ErrorCodeInvalidRequest, nil, "unable to unmarshal json: %s", err.Error()))
 				return
 			}
-
-			log.Infof("handled action %+v", action)
-			log.Tracef("response %s", string(pmBytes))
-			header := w.Header()
-			header.Set(http.CanonicalHeaderKey("content-type"), "application/json")
-			fmt.Fprint(w, string(pmBytes))
+			serveAction(w, r, game, &action)
 		} else {
 			log.Errorf("verb %s not supported for /action", r.Method)
 			http.NotFound(w, r)
 		}
-	})
+	}))
+}
+
+// serveAction applies action to game, and writes the acting player's model
+func serveAction(w http.ResponseWriter, r *http.Request, game *registeredGame, action *PlayerAction) {
+	if action.IdempotencyKey != "" && action.GetModel == nil {
+		// a retry will be waiting for this result, so see the action through even if
+		// this request is abandoned
+		game.idempotentResponses.serve(w, r, action.Me, action.IdempotencyKey, func(w http.ResponseWriter) {
+			handleAction(context.Background(), w, game.Responder, action)
+		})
+	} else {
+		handleAction(r.Context(), w, game.Responder, action)
+	}
+}
+
+func handleAction(ctx context.Context, w http.ResponseWriter, responder Responder, action *PlayerAction) {
+	ctx, cancel := context.WithTimeout(ctx, actionTimeout)
+	defer cancel()
+
+	var actionErr error
+	var player string = action.Me
+	// GetModel has nothing to apply: the playerModel is just grabbed down below
+	if action.GetModel == nil {
+		player, actionErr = responder.Act(ctx, action)
+	}
+	if actionErr != nil {
+		log.Errorf("unable to execute action: %+v", actionErr)
+		writeError(w, withCurrentModel(actionErr, responder, player))
+		return
+	}
+
+	pm, err := responder.GetPlayerModel(player)
+	if err != nil {
+		log.Errorf("unable to get player model: %+v", err)
+		writeError(w, err)
+		return
+	}
+	pmBytes, err := json.MarshalIndent(pm, "", "  ")
+	if err != nil {
+		log.Errorf("unable to serialize json: %+v", err)
+		writeError(w, err)
+		return
+	}
+
+	log.Infof("handled action %+v", action)
+	log.Tracef("response %s", string(pmBytes))
+	header := w.Header()
+	header.Set(http.CanonicalHeaderKey("content-type"), "application/json")
+	fmt.Fprint(w, string(pmBytes))
+}
+
+// withCurrentModel adds the player's up-to-date model to version conflicts, so that the
+// client can catch up without another request
+func withCurrentModel(err error, responder Responder, player string) error {
+	gameError := AsGameError(err)
+	if gameError.Code != ErrorCodeVersionConflict {
+		return err
+	}
+	pm, pmErr := responder.GetPlayerModel(player)
+	if pmErr != nil {
+		log.Errorf("unable to get player model: %+v", pmErr)
+		return err
+	}
+	details := map[string]interface{}{"PlayerModel": pm}
+	for key, value := range gameError.Details {
+		details[key] = value
+	}
+	return &GameError{Code: gameError.Code, Message: gameError.Message, Details: details}
 }
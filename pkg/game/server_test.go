@@ -0,0 +1,53 @@
+package game
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"io/ioutil"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing/fstest"
+)
+
+func RunServerTests() {
+	Describe("Server", func() {
+		get := func(server *Server, path string) (int, string) {
+			recorder := httptest.NewRecorder()
+			server.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", path, nil))
+			return recorder.Code, recorder.Body.String()
+		}
+		ui := fstest.MapFS{"main.html": &fstest.MapFile{Data: []byte("built in")}}
+
+		It("should serve the ui it was given", func() {
+			server := NewServer(&Config{}, NewGameRegistry(), ui)
+			code, body := get(server, "/main.html")
+			Expect(code).To(Equal(200))
+			Expect(body).To(Equal("built in"))
+		})
+
+		It("should serve the ui from UIDirectory instead, if it's set", func() {
+			dir, err := ioutil.TempDir("", "ui")
+			Expect(err).Should(Succeed())
+			defer os.RemoveAll(dir)
+			Expect(ioutil.WriteFile(filepath.Join(dir, "main.html"), []byte("on disk"), 0644)).Should(Succeed())
+
+			server := NewServer(&Config{UIDirectory: dir}, NewGameRegistry(), ui)
+			code, body := get(server, "/main.html")
+			Expect(code).To(Equal(200))
+			Expect(body).To(Equal("on disk"))
+		})
+
+		It("should keep servers apart", func() {
+			registry := NewGameRegistry()
+			stop := make(chan struct{})
+			defer close(stop)
+			registry.Add(DefaultGameID, NewGameConcurrencyWrapper(NewGame(), NewArchive(), stop))
+
+			code, _ := get(NewServer(&Config{}, registry, nil), "/readyz")
+			Expect(code).To(Equal(200))
+			code, _ = get(NewServer(&Config{}, NewGameRegistry(), nil), "/readyz")
+			Expect(code).To(Equal(503))
+		})
+	})
+}
@@ -0,0 +1,81 @@
+package game
+
+import (
+	"encoding/json"
+	"github.com/pkg/errors"
+)
+
+// gameSnapshot is an immutable view of a game, built by the action processor after each
+// change.  Readers share snapshots freely, so nothing in one may be modified once it's built.
+type gameSnapshot struct {
+	// Json is the whole game, serialized -- all but its finished rounds, which are in Rounds
+	Json []byte
+	// Rounds are the game's finished rounds.  A round never changes once it's finished, so
+	// they're shared with the game rather than serialized after every change.
+	Rounds         []*Round
+	PlayerModels   map[string]*PlayerModel
+	NotJoined      *PlayerModel
+	Public         *PublicModel
+	FinishedRounds []*RoundRecord
+	Archive        *Archive
+}
+
+// newGameSnapshot snapshots game and archive.  Whatever hasn't changed since previous, which
+// may be nil, is carried over rather than built again.
+func newGameSnapshot(game *Game, archive *Archive, previous *gameSnapshot) (*gameSnapshot, error) {
+	type gameJson Game
+	bytes, err := json.Marshal(&struct {
+		*gameJson
+		FinishedRounds []*Round `json:",omitempty"`
+	}{gameJson: (*gameJson)(game)})
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to serialize game")
+	}
+	playerModels := map[string]*PlayerModel{}
+	for _, player := range game.Players {
+		playerModels[player] = game.playerModel(player)
+	}
+	var finishedRounds []*RoundRecord
+	if previous != nil && previous.hasRounds(game.FinishedRounds) {
+		finishedRounds = previous.FinishedRounds
+	} else {
+		finishedRounds = game.finishedRounds()
+	}
+	return &gameSnapshot{
+		Json:           bytes,
+		Rounds:         game.FinishedRounds,
+		PlayerModels:   playerModels,
+		NotJoined:      game.playerModel(""),
+		Public:         newPublicModel(game),
+		FinishedRounds: finishedRounds,
+		// games are only ever added to the end of the archive -- it's copied when it's
+		// trimmed -- so the snapshot can share them too
+		Archive: &Archive{Games: archive.Games},
+	}, nil
+}
+
+// hasRounds is whether rounds are the snapshot's finished rounds.  Rounds are only ever added
+// to the end of a game's finished rounds, or all replaced at once, so it's enough to compare
+// the last ones.
+func (snapshot *gameSnapshot) hasRounds(rounds []*Round) bool {
+	count := len(snapshot.Rounds)
+	return count == len(rounds) && (count == 0 || snapshot.Rounds[count-1] == rounds[count-1])
+}
+
+// game rebuilds the game the snapshot was taken of
+func (snapshot *gameSnapshot) game() (*Game, error) {
+	game := &Game{}
+	err := json.Unmarshal(snapshot.Json, game)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to deserialize game")
+	}
+	game.FinishedRounds = append([]*Round{}, snapshot.Rounds...)
+	return game, nil
+}
+
+func (snapshot *gameSnapshot) playerModel(player string) *PlayerModel {
+	if pm, ok := snapshot.PlayerModels[player]; ok {
+		return pm
+	}
+	return snapshot.NotJoined
+}
@@ -0,0 +1,295 @@
+package game
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const tcpHelp = `commands:
+  JOIN <name>        join the game; later commands are done as name
+  STATUS             show the game
+  DECK <type>        set the deck type: Standard or DoubleStandard
+  CARDS <count>      set the number of cards per player
+  START              start a round
+  WAGER <hands>      wager on how many hands you'll win
+  PLAY <card>        play a card, such as QH or 10S
+  FINISH             finish the round
+  ENDGAME            finish the game, archiving its scores
+  REMOVE <player>    remove a player
+  HELP               show this message
+  QUIT               disconnect
+every reply ends with a line starting with OK or ERR`
+
+// TCPServer speaks a line-oriented text protocol, for clients that would rather not deal with
+// HTTP -- nc, scripts, bots.  Each line is a command; each reply is zero or more lines of
+// output, followed by a line that's either "OK" or "ERR <code> <message>".
+type TCPServer struct {
+	Responder Responder
+	mutex     sync.Mutex
+	listener  net.Listener
+	conns     map[net.Conn]bool
+	handlers  sync.WaitGroup
+}
+
+func NewTCPServer(responder Responder) *TCPServer {
+	return &TCPServer{Responder: responder, conns: map[net.Conn]bool{}}
+}
+
+// Serve accepts connections on listener until Shutdown is called
+func (ts *TCPServer) Serve(listener net.Listener) error {
+	ts.mutex.Lock()
+	ts.listener = listener
+	ts.mutex.Unlock()
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			ts.mutex.Lock()
+			closed := ts.listener == nil
+			ts.mutex.Unlock()
+			if closed {
+				return nil
+			}
+			return errors.Wrapf(err, "unable to accept tcp connection")
+		}
+		ts.mutex.Lock()
+		if ts.listener == nil {
+			// shut down while this connection was being accepted
+			ts.mutex.Unlock()
+			conn.Close()
+			return nil
+		}
+		ts.conns[conn] = true
+		ts.handlers.Add(1)
+		ts.mutex.Unlock()
+		go func() {
+			defer ts.handlers.Done()
+			ts.handleConnection(conn)
+			ts.mutex.Lock()
+			delete(ts.conns, conn)
+			ts.mutex.Unlock()
+		}()
+	}
+}
+
+// Shutdown stops accepting connections, closes the open ones, and waits for their in-flight
+// commands to finish
+func (ts *TCPServer) Shutdown(ctx context.Context) error {
+	ts.mutex.Lock()
+	if ts.listener != nil {
+		ts.listener.Close()
+		ts.listener = nil
+	}
+	for conn := range ts.conns {
+		conn.Close()
+	}
+	ts.mutex.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		ts.handlers.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// tcpSession is one connection's state
+type tcpSession struct {
+	Me string
+}
+
+func (ts *TCPServer) handleConnection(conn net.Conn) {
+	defer conn.Close()
+	log.Infof("accepted tcp connection from %s", conn.RemoteAddr())
+	session := &tcpSession{}
+	writer := bufio.NewWriter(conn)
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		command := strings.ToUpper(fields[0])
+		if command == "QUIT" {
+			fmt.Fprintln(writer, "OK")
+			writer.Flush()
+			break
+		}
+		lines, err := ts.execute(session, command, fields[1:])
+		for _, line := range lines {
+			fmt.Fprintln(writer, line)
+		}
+		if err != nil {
+			gameError := AsGameError(err)
+			fmt.Fprintf(writer, "ERR %s %s\n", gameError.Code, gameError.Message)
+		} else {
+			fmt.Fprintln(writer, "OK")
+		}
+		if err := writer.Flush(); err != nil {
+			log.Infof("unable to write to %s: %s", conn.RemoteAddr(), err)
+			break
+		}
+	}
+	log.Infof("closed tcp connection from %s", conn.RemoteAddr())
+}
+
+func tcpUsage(usage string) error {
+	return newGameError(ErrorCodeInvalidRequest, nil, "usage: %s", usage)
+}
+
+func tcpIntArg(args []string, usage string) (int, error) {
+	if len(args) != 1 {
+		return 0, tcpUsage(usage)
+	}
+	value, err := strconv.Atoi(args[0])
+	if err != nil {
+		return 0, tcpUsage(usage)
+	}
+	return value, nil
+}
+
+func (ts *TCPServer) execute(session *tcpSession, command string, args []string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), actionTimeout)
+	defer cancel()
+	responder := ts.Responder
+
+	if command == "HELP" {
+		return strings.Split(tcpHelp, "\n"), nil
+	}
+	if command == "JOIN" {
+		if len(args) != 1 {
+			return nil, tcpUsage("JOIN <name>")
+		}
+		player, err := responder.Join(ctx, args[0])
+		if err != nil {
+			return nil, err
+		}
+		session.Me = player
+		return []string{fmt.Sprintf("joined as %s", player)}, nil
+	}
+	if command != "STATUS" && session.Me == "" {
+		return nil, newGameError(ErrorCodeInvalidRequest, nil, "JOIN first")
+	}
+
+	var err error
+	switch command {
+	case "STATUS":
+		var pm *PlayerModel
+		pm, err = responder.GetPlayerModel(session.Me)
+		if err != nil {
+			return nil, err
+		}
+		return formatPlayerModel(pm), nil
+	case "DECK":
+		var deckType DeckType
+		if len(args) != 1 {
+			return nil, tcpUsage("DECK <type>")
+		}
+		if parseErr := deckType.UnmarshalText([]byte(args[0])); parseErr != nil {
+			return nil, newGameError(ErrorCodeInvalidDeck, nil, "%s", parseErr.Error())
+		}
+		err = responder.SetDeckType(ctx, deckType)
+	case "CARDS":
+		var count int
+		if count, err = tcpIntArg(args, "CARDS <count>"); err == nil {
+			err = responder.SetCardsPerPlayer(ctx, count)
+		}
+	case "START":
+		err = responder.StartRound(ctx)
+	case "WAGER":
+		var hands int
+		if hands, err = tcpIntArg(args, "WAGER <hands>"); err == nil {
+			err = responder.MakeWager(ctx, session.Me, hands)
+		}
+	case "PLAY":
+		if len(args) != 1 {
+			return nil, tcpUsage("PLAY <card>")
+		}
+		var pm *PlayerModel
+		pm, err = responder.GetPlayerModel(session.Me)
+		if err != nil {
+			return nil, err
+		}
+		var card *Card
+		card, err = ParseCard(pm.Deck(), args[0])
+		if err != nil {
+			return nil, err
+		}
+		err = responder.PlayCard(ctx, session.Me, card)
+	case "FINISH":
+		err = responder.FinishRound(ctx)
+	case "ENDGAME":
+		err = responder.FinishGame(ctx)
+	case "REMOVE":
+		if len(args) != 1 {
+			return nil, tcpUsage("REMOVE <player>")
+		}
+		err = responder.RemovePlayer(ctx, args[0])
+	default:
+		return nil, newGameError(ErrorCodeInvalidRequest, nil, "unrecognized command %s; try HELP", command)
+	}
+	return nil, err
+}
+
+func optionalCard(deck Deck, card *Card) string {
+	if card == nil {
+		return "-"
+	}
+	return FormatCard(deck, card)
+}
+
+func optionalIntString(i *int) string {
+	if i == nil {
+		return "-"
+	}
+	return strconv.Itoa(*i)
+}
+
+// formatPlayerModel writes a model as "KEY value" lines, so that it's easy to read -- by
+// people, or by scripts
+func formatPlayerModel(pm *PlayerModel) []string {
+	deck := pm.Deck()
+	lines := []string{
+		fmt.Sprintf("VERSION %d", pm.Version),
+		fmt.Sprintf("STATE %s", pm.State.JSONString()),
+		fmt.Sprintf("PLAYERS %s", strings.Join(pm.Game.Players, " ")),
+		fmt.Sprintf("DECK %s", pm.Game.DeckType.JSONString()),
+		fmt.Sprintf("CARDS %d", pm.Game.CardsPerPlayer),
+	}
+	if pm.Announcement != "" {
+		lines = append(lines, fmt.Sprintf("ANNOUNCEMENT %s", pm.Announcement))
+	}
+	if pm.Status == nil {
+		return lines
+	}
+	lines = append(lines, fmt.Sprintf("TRUMP %s", pm.Status.TrumpSuit))
+	if hand := pm.Status.CurrentHand; hand != nil && hand.Suit != "" {
+		lines = append(lines, fmt.Sprintf("SUIT %s", hand.Suit))
+	}
+	for _, ps := range pm.Status.PlayerStatuses {
+		lines = append(lines, fmt.Sprintf("PLAYER %s wager=%s won=%s mood=%s card=%s", ps.Player, optionalIntString(ps.Wager), optionalIntString(ps.HandsWon), ps.Mood.JSONString(), optionalCard(deck, ps.CurrentCard)))
+	}
+	lines = append(lines, fmt.Sprintf("HAND %s", FormatCards(deck, pm.MyCards)))
+	if len(pm.LegalWagers) > 0 {
+		wagers := []string{}
+		for _, wager := range pm.LegalWagers {
+			wagers = append(wagers, strconv.Itoa(wager))
+		}
+		lines = append(lines, fmt.Sprintf("LEGALWAGERS %s", strings.Join(wagers, " ")))
+	}
+	if len(pm.LegalCards) > 0 {
+		lines = append(lines, fmt.Sprintf("LEGALCARDS %s", FormatCards(deck, pm.LegalCards)))
+	}
+	return lines
+}
@@ -0,0 +1,79 @@
+package game
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"net"
+	"strings"
+)
+
+type tcpTestClient struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// send writes a command, and reads lines up to and including the OK or ERR that ends the reply
+func (client *tcpTestClient) send(command string) []string {
+	_, err := fmt.Fprintf(client.conn, "%s\n", command)
+	Expect(err).Should(Succeed())
+	lines := []string{}
+	for {
+		line, err := client.reader.ReadString('\n')
+		Expect(err).Should(Succeed())
+		line = strings.TrimRight(line, "\n")
+		lines = append(lines, line)
+		if line == "OK" || strings.HasPrefix(line, "ERR ") {
+			return lines
+		}
+	}
+}
+
+func RunTCPServerTests() {
+	Describe("TCPServer", func() {
+		It("should play a round over the text protocol", func() {
+			stop := make(chan struct{})
+			defer close(stop)
+			gcw := NewGameConcurrencyWrapper(NewGame(), NewArchive(), stop)
+			server := NewTCPServer(gcw)
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).Should(Succeed())
+			go server.Serve(listener)
+			defer server.Shutdown(context.Background())
+
+			clients := map[string]*tcpTestClient{}
+			for _, player := range []string{"abc", "def"} {
+				conn, err := net.Dial("tcp", listener.Addr().String())
+				Expect(err).Should(Succeed())
+				defer conn.Close()
+				clients[player] = &tcpTestClient{conn: conn, reader: bufio.NewReader(conn)}
+			}
+			abc, def := clients["abc"], clients["def"]
+
+			Expect(abc.send("WAGER 1")).To(Equal([]string{"ERR InvalidRequest JOIN first"}))
+			Expect(abc.send("join abc")).To(Equal([]string{"joined as abc", "OK"}))
+			Expect(def.send("JOIN def")).To(Equal([]string{"joined as def", "OK"}))
+			Expect(abc.send("CARDS 1")).To(Equal([]string{"OK"}))
+			Expect(abc.send("START")).To(Equal([]string{"OK"}))
+
+			Expect(def.send("WAGER 0")[0]).To(HavePrefix("ERR NotYourTurn"))
+			Expect(abc.send("WAGER 0")).To(Equal([]string{"OK"}))
+			status := def.send("STATUS")
+			Expect(status).To(ContainElement("STATE WagerTurn"))
+			Expect(status).To(ContainElement("LEGALWAGERS 0"))
+			Expect(def.send("WAGER 0")).To(Equal([]string{"OK"}))
+
+			Expect(abc.send("PLAY ZZ")[0]).To(HavePrefix("ERR InvalidCard"))
+			for _, player := range []string{"abc", "def"} {
+				pm, err := gcw.GetPlayerModel(player)
+				Expect(err).Should(Succeed())
+				Expect(clients[player].send("PLAY " + pm.LegalCards[0].String())).To(Equal([]string{"OK"}))
+			}
+			Expect(abc.send("STATUS")).To(ContainElement("STATE RoundFinished"))
+			Expect(abc.send("FINISH")).To(Equal([]string{"OK"}))
+			Expect(abc.send("QUIT")).To(Equal([]string{"OK"}))
+		})
+	})
+}
@@ -0,0 +1,1672 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: game.proto
+
+package gamepb
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	wrappers "github.com/golang/protobuf/ptypes/wrappers"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// ActionOptions are the options shared by every action
+type ActionOptions struct {
+	// if set, the action is only applied if the game is still at this version
+	ExpectedVersion *wrappers.Int32Value `protobuf:"bytes,1,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	// if set, makes retries safe: an action is applied at most once for each of a player's
+	// recent keys, and repeats get the original response.  Keys are separate from the HTTP API's.
+	IdempotencyKey       string   `protobuf:"bytes,2,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ActionOptions) Reset()         { *m = ActionOptions{} }
+func (m *ActionOptions) String() string { return proto.CompactTextString(m) }
+func (*ActionOptions) ProtoMessage()    {}
+func (*ActionOptions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_38fc58335341d769, []int{0}
+}
+
+func (m *ActionOptions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ActionOptions.Unmarshal(m, b)
+}
+func (m *ActionOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ActionOptions.Marshal(b, m, deterministic)
+}
+func (m *ActionOptions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ActionOptions.Merge(m, src)
+}
+func (m *ActionOptions) XXX_Size() int {
+	return xxx_messageInfo_ActionOptions.Size(m)
+}
+func (m *ActionOptions) XXX_DiscardUnknown() {
+	xxx_messageInfo_ActionOptions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ActionOptions proto.InternalMessageInfo
+
+func (m *ActionOptions) GetExpectedVersion() *wrappers.Int32Value {
+	if m != nil {
+		return m.ExpectedVersion
+	}
+	return nil
+}
+
+func (m *ActionOptions) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
+type PlayerRequest struct {
+	Me                   string         `protobuf:"bytes,1,opt,name=me,proto3" json:"me,omitempty"`
+	Options              *ActionOptions `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *PlayerRequest) Reset()         { *m = PlayerRequest{} }
+func (m *PlayerRequest) String() string { return proto.CompactTextString(m) }
+func (*PlayerRequest) ProtoMessage()    {}
+func (*PlayerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_38fc58335341d769, []int{1}
+}
+
+func (m *PlayerRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PlayerRequest.Unmarshal(m, b)
+}
+func (m *PlayerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PlayerRequest.Marshal(b, m, deterministic)
+}
+func (m *PlayerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlayerRequest.Merge(m, src)
+}
+func (m *PlayerRequest) XXX_Size() int {
+	return xxx_messageInfo_PlayerRequest.Size(m)
+}
+func (m *PlayerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlayerRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlayerRequest proto.InternalMessageInfo
+
+func (m *PlayerRequest) GetMe() string {
+	if m != nil {
+		return m.Me
+	}
+	return ""
+}
+
+func (m *PlayerRequest) GetOptions() *ActionOptions {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+type JoinRequest struct {
+	Me                   string   `protobuf:"bytes,1,opt,name=me,proto3" json:"me,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *JoinRequest) Reset()         { *m = JoinRequest{} }
+func (m *JoinRequest) String() string { return proto.CompactTextString(m) }
+func (*JoinRequest) ProtoMessage()    {}
+func (*JoinRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_38fc58335341d769, []int{2}
+}
+
+func (m *JoinRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JoinRequest.Unmarshal(m, b)
+}
+func (m *JoinRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JoinRequest.Marshal(b, m, deterministic)
+}
+func (m *JoinRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JoinRequest.Merge(m, src)
+}
+func (m *JoinRequest) XXX_Size() int {
+	return xxx_messageInfo_JoinRequest.Size(m)
+}
+func (m *JoinRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_JoinRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JoinRequest proto.InternalMessageInfo
+
+func (m *JoinRequest) GetMe() string {
+	if m != nil {
+		return m.Me
+	}
+	return ""
+}
+
+type RemovePlayerRequest struct {
+	Me                   string         `protobuf:"bytes,1,opt,name=me,proto3" json:"me,omitempty"`
+	Player               string         `protobuf:"bytes,2,opt,name=player,proto3" json:"player,omitempty"`
+	Options              *ActionOptions `protobuf:"bytes,3,opt,name=options,proto3" json:"options,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *RemovePlayerRequest) Reset()         { *m = RemovePlayerRequest{} }
+func (m *RemovePlayerRequest) String() string { return proto.CompactTextString(m) }
+func (*RemovePlayerRequest) ProtoMessage()    {}
+func (*RemovePlayerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_38fc58335341d769, []int{3}
+}
+
+func (m *RemovePlayerRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemovePlayerRequest.Unmarshal(m, b)
+}
+func (m *RemovePlayerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemovePlayerRequest.Marshal(b, m, deterministic)
+}
+func (m *RemovePlayerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemovePlayerRequest.Merge(m, src)
+}
+func (m *RemovePlayerRequest) XXX_Size() int {
+	return xxx_messageInfo_RemovePlayerRequest.Size(m)
+}
+func (m *RemovePlayerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemovePlayerRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemovePlayerRequest proto.InternalMessageInfo
+
+func (m *RemovePlayerRequest) GetMe() string {
+	if m != nil {
+		return m.Me
+	}
+	return ""
+}
+
+func (m *RemovePlayerRequest) GetPlayer() string {
+	if m != nil {
+		return m.Player
+	}
+	return ""
+}
+
+func (m *RemovePlayerRequest) GetOptions() *ActionOptions {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+type SetCardsPerPlayerRequest struct {
+	Me                   string         `protobuf:"bytes,1,opt,name=me,proto3" json:"me,omitempty"`
+	Count                int32          `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Options              *ActionOptions `protobuf:"bytes,3,opt,name=options,proto3" json:"options,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *SetCardsPerPlayerRequest) Reset()         { *m = SetCardsPerPlayerRequest{} }
+func (m *SetCardsPerPlayerRequest) String() string { return proto.CompactTextString(m) }
+func (*SetCardsPerPlayerRequest) ProtoMessage()    {}
+func (*SetCardsPerPlayerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_38fc58335341d769, []int{4}
+}
+
+func (m *SetCardsPerPlayerRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetCardsPerPlayerRequest.Unmarshal(m, b)
+}
+func (m *SetCardsPerPlayerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetCardsPerPlayerRequest.Marshal(b, m, deterministic)
+}
+func (m *SetCardsPerPlayerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetCardsPerPlayerRequest.Merge(m, src)
+}
+func (m *SetCardsPerPlayerRequest) XXX_Size() int {
+	return xxx_messageInfo_SetCardsPerPlayerRequest.Size(m)
+}
+func (m *SetCardsPerPlayerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetCardsPerPlayerRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetCardsPerPlayerRequest proto.InternalMessageInfo
+
+func (m *SetCardsPerPlayerRequest) GetMe() string {
+	if m != nil {
+		return m.Me
+	}
+	return ""
+}
+
+func (m *SetCardsPerPlayerRequest) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *SetCardsPerPlayerRequest) GetOptions() *ActionOptions {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+type SetDeckTypeRequest struct {
+	Me string `protobuf:"bytes,1,opt,name=me,proto3" json:"me,omitempty"`
+	// one of Standard, DoubleStandard
+	DeckType             string         `protobuf:"bytes,2,opt,name=deck_type,json=deckType,proto3" json:"deck_type,omitempty"`
+	Options              *ActionOptions `protobuf:"bytes,3,opt,name=options,proto3" json:"options,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *SetDeckTypeRequest) Reset()         { *m = SetDeckTypeRequest{} }
+func (m *SetDeckTypeRequest) String() string { return proto.CompactTextString(m) }
+func (*SetDeckTypeRequest) ProtoMessage()    {}
+func (*SetDeckTypeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_38fc58335341d769, []int{5}
+}
+
+func (m *SetDeckTypeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetDeckTypeRequest.Unmarshal(m, b)
+}
+func (m *SetDeckTypeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetDeckTypeRequest.Marshal(b, m, deterministic)
+}
+func (m *SetDeckTypeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetDeckTypeRequest.Merge(m, src)
+}
+func (m *SetDeckTypeRequest) XXX_Size() int {
+	return xxx_messageInfo_SetDeckTypeRequest.Size(m)
+}
+func (m *SetDeckTypeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetDeckTypeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetDeckTypeRequest proto.InternalMessageInfo
+
+func (m *SetDeckTypeRequest) GetMe() string {
+	if m != nil {
+		return m.Me
+	}
+	return ""
+}
+
+func (m *SetDeckTypeRequest) GetDeckType() string {
+	if m != nil {
+		return m.DeckType
+	}
+	return ""
+}
+
+func (m *SetDeckTypeRequest) GetOptions() *ActionOptions {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+type MakeWagerRequest struct {
+	Me                   string         `protobuf:"bytes,1,opt,name=me,proto3" json:"me,omitempty"`
+	Hands                int32          `protobuf:"varint,2,opt,name=hands,proto3" json:"hands,omitempty"`
+	Options              *ActionOptions `protobuf:"bytes,3,opt,name=options,proto3" json:"options,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *MakeWagerRequest) Reset()         { *m = MakeWagerRequest{} }
+func (m *MakeWagerRequest) String() string { return proto.CompactTextString(m) }
+func (*MakeWagerRequest) ProtoMessage()    {}
+func (*MakeWagerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_38fc58335341d769, []int{6}
+}
+
+func (m *MakeWagerRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MakeWagerRequest.Unmarshal(m, b)
+}
+func (m *MakeWagerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MakeWagerRequest.Marshal(b, m, deterministic)
+}
+func (m *MakeWagerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MakeWagerRequest.Merge(m, src)
+}
+func (m *MakeWagerRequest) XXX_Size() int {
+	return xxx_messageInfo_MakeWagerRequest.Size(m)
+}
+func (m *MakeWagerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MakeWagerRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MakeWagerRequest proto.InternalMessageInfo
+
+func (m *MakeWagerRequest) GetMe() string {
+	if m != nil {
+		return m.Me
+	}
+	return ""
+}
+
+func (m *MakeWagerRequest) GetHands() int32 {
+	if m != nil {
+		return m.Hands
+	}
+	return 0
+}
+
+func (m *MakeWagerRequest) GetOptions() *ActionOptions {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+type PlayCardRequest struct {
+	Me                   string         `protobuf:"bytes,1,opt,name=me,proto3" json:"me,omitempty"`
+	Card                 *Card          `protobuf:"bytes,2,opt,name=card,proto3" json:"card,omitempty"`
+	Options              *ActionOptions `protobuf:"bytes,3,opt,name=options,proto3" json:"options,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *PlayCardRequest) Reset()         { *m = PlayCardRequest{} }
+func (m *PlayCardRequest) String() string { return proto.CompactTextString(m) }
+func (*PlayCardRequest) ProtoMessage()    {}
+func (*PlayCardRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_38fc58335341d769, []int{7}
+}
+
+func (m *PlayCardRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PlayCardRequest.Unmarshal(m, b)
+}
+func (m *PlayCardRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PlayCardRequest.Marshal(b, m, deterministic)
+}
+func (m *PlayCardRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlayCardRequest.Merge(m, src)
+}
+func (m *PlayCardRequest) XXX_Size() int {
+	return xxx_messageInfo_PlayCardRequest.Size(m)
+}
+func (m *PlayCardRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlayCardRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlayCardRequest proto.InternalMessageInfo
+
+func (m *PlayCardRequest) GetMe() string {
+	if m != nil {
+		return m.Me
+	}
+	return ""
+}
+
+func (m *PlayCardRequest) GetCard() *Card {
+	if m != nil {
+		return m.Card
+	}
+	return nil
+}
+
+func (m *PlayCardRequest) GetOptions() *ActionOptions {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+type Card struct {
+	Suit                 string   `protobuf:"bytes,1,opt,name=suit,proto3" json:"suit,omitempty"`
+	Number               string   `protobuf:"bytes,2,opt,name=number,proto3" json:"number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Card) Reset()         { *m = Card{} }
+func (m *Card) String() string { return proto.CompactTextString(m) }
+func (*Card) ProtoMessage()    {}
+func (*Card) Descriptor() ([]byte, []int) {
+	return fileDescriptor_38fc58335341d769, []int{8}
+}
+
+func (m *Card) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Card.Unmarshal(m, b)
+}
+func (m *Card) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Card.Marshal(b, m, deterministic)
+}
+func (m *Card) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Card.Merge(m, src)
+}
+func (m *Card) XXX_Size() int {
+	return xxx_messageInfo_Card.Size(m)
+}
+func (m *Card) XXX_DiscardUnknown() {
+	xxx_messageInfo_Card.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Card proto.InternalMessageInfo
+
+func (m *Card) GetSuit() string {
+	if m != nil {
+		return m.Suit
+	}
+	return ""
+}
+
+func (m *Card) GetNumber() string {
+	if m != nil {
+		return m.Number
+	}
+	return ""
+}
+
+type CardPlay struct {
+	Player               string   `protobuf:"bytes,1,opt,name=player,proto3" json:"player,omitempty"`
+	Card                 *Card    `protobuf:"bytes,2,opt,name=card,proto3" json:"card,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CardPlay) Reset()         { *m = CardPlay{} }
+func (m *CardPlay) String() string { return proto.CompactTextString(m) }
+func (*CardPlay) ProtoMessage()    {}
+func (*CardPlay) Descriptor() ([]byte, []int) {
+	return fileDescriptor_38fc58335341d769, []int{9}
+}
+
+func (m *CardPlay) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CardPlay.Unmarshal(m, b)
+}
+func (m *CardPlay) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CardPlay.Marshal(b, m, deterministic)
+}
+func (m *CardPlay) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CardPlay.Merge(m, src)
+}
+func (m *CardPlay) XXX_Size() int {
+	return xxx_messageInfo_CardPlay.Size(m)
+}
+func (m *CardPlay) XXX_DiscardUnknown() {
+	xxx_messageInfo_CardPlay.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CardPlay proto.InternalMessageInfo
+
+func (m *CardPlay) GetPlayer() string {
+	if m != nil {
+		return m.Player
+	}
+	return ""
+}
+
+func (m *CardPlay) GetCard() *Card {
+	if m != nil {
+		return m.Card
+	}
+	return nil
+}
+
+type PlayerGame struct {
+	Players           []string `protobuf:"bytes,1,rep,name=players,proto3" json:"players,omitempty"`
+	MaxCardsPerPlayer int32    `protobuf:"varint,2,opt,name=max_cards_per_player,json=maxCardsPerPlayer,proto3" json:"max_cards_per_player,omitempty"`
+	CardsPerPlayer    int32    `protobuf:"varint,3,opt,name=cards_per_player,json=cardsPerPlayer,proto3" json:"cards_per_player,omitempty"`
+	DeckType          string   `protobuf:"bytes,4,opt,name=deck_type,json=deckType,proto3" json:"deck_type,omitempty"`
+	// identifies the game; versions only go up for the same guid
+	Guid                 string   `protobuf:"bytes,5,opt,name=guid,proto3" json:"guid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PlayerGame) Reset()         { *m = PlayerGame{} }
+func (m *PlayerGame) String() string { return proto.CompactTextString(m) }
+func (*PlayerGame) ProtoMessage()    {}
+func (*PlayerGame) Descriptor() ([]byte, []int) {
+	return fileDescriptor_38fc58335341d769, []int{10}
+}
+
+func (m *PlayerGame) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PlayerGame.Unmarshal(m, b)
+}
+func (m *PlayerGame) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PlayerGame.Marshal(b, m, deterministic)
+}
+func (m *PlayerGame) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlayerGame.Merge(m, src)
+}
+func (m *PlayerGame) XXX_Size() int {
+	return xxx_messageInfo_PlayerGame.Size(m)
+}
+func (m *PlayerGame) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlayerGame.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlayerGame proto.InternalMessageInfo
+
+func (m *PlayerGame) GetPlayers() []string {
+	if m != nil {
+		return m.Players
+	}
+	return nil
+}
+
+func (m *PlayerGame) GetMaxCardsPerPlayer() int32 {
+	if m != nil {
+		return m.MaxCardsPerPlayer
+	}
+	return 0
+}
+
+func (m *PlayerGame) GetCardsPerPlayer() int32 {
+	if m != nil {
+		return m.CardsPerPlayer
+	}
+	return 0
+}
+
+func (m *PlayerGame) GetDeckType() string {
+	if m != nil {
+		return m.DeckType
+	}
+	return ""
+}
+
+func (m *PlayerGame) GetGuid() string {
+	if m != nil {
+		return m.Guid
+	}
+	return ""
+}
+
+type PlayerStatus struct {
+	Player               string               `protobuf:"bytes,1,opt,name=player,proto3" json:"player,omitempty"`
+	IsMe                 bool                 `protobuf:"varint,2,opt,name=is_me,json=isMe,proto3" json:"is_me,omitempty"`
+	IsNextWagerer        bool                 `protobuf:"varint,3,opt,name=is_next_wagerer,json=isNextWagerer,proto3" json:"is_next_wagerer,omitempty"`
+	IsNextPlayer         bool                 `protobuf:"varint,4,opt,name=is_next_player,json=isNextPlayer,proto3" json:"is_next_player,omitempty"`
+	IsCurrentLeader      bool                 `protobuf:"varint,5,opt,name=is_current_leader,json=isCurrentLeader,proto3" json:"is_current_leader,omitempty"`
+	IsPreviousWinner     bool                 `protobuf:"varint,6,opt,name=is_previous_winner,json=isPreviousWinner,proto3" json:"is_previous_winner,omitempty"`
+	Mood                 string               `protobuf:"bytes,7,opt,name=mood,proto3" json:"mood,omitempty"`
+	Wager                *wrappers.Int32Value `protobuf:"bytes,8,opt,name=wager,proto3" json:"wager,omitempty"`
+	HandsWon             *wrappers.Int32Value `protobuf:"bytes,9,opt,name=hands_won,json=handsWon,proto3" json:"hands_won,omitempty"`
+	PreviousCard         *Card                `protobuf:"bytes,10,opt,name=previous_card,json=previousCard,proto3" json:"previous_card,omitempty"`
+	CurrentCard          *Card                `protobuf:"bytes,11,opt,name=current_card,json=currentCard,proto3" json:"current_card,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *PlayerStatus) Reset()         { *m = PlayerStatus{} }
+func (m *PlayerStatus) String() string { return proto.CompactTextString(m) }
+func (*PlayerStatus) ProtoMessage()    {}
+func (*PlayerStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_38fc58335341d769, []int{11}
+}
+
+func (m *PlayerStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PlayerStatus.Unmarshal(m, b)
+}
+func (m *PlayerStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PlayerStatus.Marshal(b, m, deterministic)
+}
+func (m *PlayerStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlayerStatus.Merge(m, src)
+}
+func (m *PlayerStatus) XXX_Size() int {
+	return xxx_messageInfo_PlayerStatus.Size(m)
+}
+func (m *PlayerStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlayerStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlayerStatus proto.InternalMessageInfo
+
+func (m *PlayerStatus) GetPlayer() string {
+	if m != nil {
+		return m.Player
+	}
+	return ""
+}
+
+func (m *PlayerStatus) GetIsMe() bool {
+	if m != nil {
+		return m.IsMe
+	}
+	return false
+}
+
+func (m *PlayerStatus) GetIsNextWagerer() bool {
+	if m != nil {
+		return m.IsNextWagerer
+	}
+	return false
+}
+
+func (m *PlayerStatus) GetIsNextPlayer() bool {
+	if m != nil {
+		return m.IsNextPlayer
+	}
+	return false
+}
+
+func (m *PlayerStatus) GetIsCurrentLeader() bool {
+	if m != nil {
+		return m.IsCurrentLeader
+	}
+	return false
+}
+
+func (m *PlayerStatus) GetIsPreviousWinner() bool {
+	if m != nil {
+		return m.IsPreviousWinner
+	}
+	return false
+}
+
+func (m *PlayerStatus) GetMood() string {
+	if m != nil {
+		return m.Mood
+	}
+	return ""
+}
+
+func (m *PlayerStatus) GetWager() *wrappers.Int32Value {
+	if m != nil {
+		return m.Wager
+	}
+	return nil
+}
+
+func (m *PlayerStatus) GetHandsWon() *wrappers.Int32Value {
+	if m != nil {
+		return m.HandsWon
+	}
+	return nil
+}
+
+func (m *PlayerStatus) GetPreviousCard() *Card {
+	if m != nil {
+		return m.PreviousCard
+	}
+	return nil
+}
+
+func (m *PlayerStatus) GetCurrentCard() *Card {
+	if m != nil {
+		return m.CurrentCard
+	}
+	return nil
+}
+
+type CurrentHand struct {
+	Suit                 string      `protobuf:"bytes,1,opt,name=suit,proto3" json:"suit,omitempty"`
+	Leader               string      `protobuf:"bytes,2,opt,name=leader,proto3" json:"leader,omitempty"`
+	LeaderCard           *Card       `protobuf:"bytes,3,opt,name=leader_card,json=leaderCard,proto3" json:"leader_card,omitempty"`
+	NextPlayer           string      `protobuf:"bytes,4,opt,name=next_player,json=nextPlayer,proto3" json:"next_player,omitempty"`
+	Plays                []*CardPlay `protobuf:"bytes,5,rep,name=plays,proto3" json:"plays,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *CurrentHand) Reset()         { *m = CurrentHand{} }
+func (m *CurrentHand) String() string { return proto.CompactTextString(m) }
+func (*CurrentHand) ProtoMessage()    {}
+func (*CurrentHand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_38fc58335341d769, []int{12}
+}
+
+func (m *CurrentHand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CurrentHand.Unmarshal(m, b)
+}
+func (m *CurrentHand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CurrentHand.Marshal(b, m, deterministic)
+}
+func (m *CurrentHand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CurrentHand.Merge(m, src)
+}
+func (m *CurrentHand) XXX_Size() int {
+	return xxx_messageInfo_CurrentHand.Size(m)
+}
+func (m *CurrentHand) XXX_DiscardUnknown() {
+	xxx_messageInfo_CurrentHand.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CurrentHand proto.InternalMessageInfo
+
+func (m *CurrentHand) GetSuit() string {
+	if m != nil {
+		return m.Suit
+	}
+	return ""
+}
+
+func (m *CurrentHand) GetLeader() string {
+	if m != nil {
+		return m.Leader
+	}
+	return ""
+}
+
+func (m *CurrentHand) GetLeaderCard() *Card {
+	if m != nil {
+		return m.LeaderCard
+	}
+	return nil
+}
+
+func (m *CurrentHand) GetNextPlayer() string {
+	if m != nil {
+		return m.NextPlayer
+	}
+	return ""
+}
+
+func (m *CurrentHand) GetPlays() []*CardPlay {
+	if m != nil {
+		return m.Plays
+	}
+	return nil
+}
+
+type PreviousHand struct {
+	Suit                 string   `protobuf:"bytes,1,opt,name=suit,proto3" json:"suit,omitempty"`
+	Winner               string   `protobuf:"bytes,2,opt,name=winner,proto3" json:"winner,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PreviousHand) Reset()         { *m = PreviousHand{} }
+func (m *PreviousHand) String() string { return proto.CompactTextString(m) }
+func (*PreviousHand) ProtoMessage()    {}
+func (*PreviousHand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_38fc58335341d769, []int{13}
+}
+
+func (m *PreviousHand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PreviousHand.Unmarshal(m, b)
+}
+func (m *PreviousHand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PreviousHand.Marshal(b, m, deterministic)
+}
+func (m *PreviousHand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PreviousHand.Merge(m, src)
+}
+func (m *PreviousHand) XXX_Size() int {
+	return xxx_messageInfo_PreviousHand.Size(m)
+}
+func (m *PreviousHand) XXX_DiscardUnknown() {
+	xxx_messageInfo_PreviousHand.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PreviousHand proto.InternalMessageInfo
+
+func (m *PreviousHand) GetSuit() string {
+	if m != nil {
+		return m.Suit
+	}
+	return ""
+}
+
+func (m *PreviousHand) GetWinner() string {
+	if m != nil {
+		return m.Winner
+	}
+	return ""
+}
+
+type Trick struct {
+	Suit   string      `protobuf:"bytes,1,opt,name=suit,proto3" json:"suit,omitempty"`
+	Cards  []*CardPlay `protobuf:"bytes,2,rep,name=cards,proto3" json:"cards,omitempty"`
+	Winner string      `protobuf:"bytes,3,opt,name=winner,proto3" json:"winner,omitempty"`
+	// the cards, in order, in short notation -- "QH 10H AS"
+	Notation             string   `protobuf:"bytes,4,opt,name=notation,proto3" json:"notation,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Trick) Reset()         { *m = Trick{} }
+func (m *Trick) String() string { return proto.CompactTextString(m) }
+func (*Trick) ProtoMessage()    {}
+func (*Trick) Descriptor() ([]byte, []int) {
+	return fileDescriptor_38fc58335341d769, []int{14}
+}
+
+func (m *Trick) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Trick.Unmarshal(m, b)
+}
+func (m *Trick) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Trick.Marshal(b, m, deterministic)
+}
+func (m *Trick) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Trick.Merge(m, src)
+}
+func (m *Trick) XXX_Size() int {
+	return xxx_messageInfo_Trick.Size(m)
+}
+func (m *Trick) XXX_DiscardUnknown() {
+	xxx_messageInfo_Trick.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Trick proto.InternalMessageInfo
+
+func (m *Trick) GetSuit() string {
+	if m != nil {
+		return m.Suit
+	}
+	return ""
+}
+
+func (m *Trick) GetCards() []*CardPlay {
+	if m != nil {
+		return m.Cards
+	}
+	return nil
+}
+
+func (m *Trick) GetWinner() string {
+	if m != nil {
+		return m.Winner
+	}
+	return ""
+}
+
+func (m *Trick) GetNotation() string {
+	if m != nil {
+		return m.Notation
+	}
+	return ""
+}
+
+type Status struct {
+	PlayerStatuses       []*PlayerStatus `protobuf:"bytes,1,rep,name=player_statuses,json=playerStatuses,proto3" json:"player_statuses,omitempty"`
+	TrumpSuit            string          `protobuf:"bytes,2,opt,name=trump_suit,json=trumpSuit,proto3" json:"trump_suit,omitempty"`
+	NextWagerPlayer      string          `protobuf:"bytes,3,opt,name=next_wager_player,json=nextWagerPlayer,proto3" json:"next_wager_player,omitempty"`
+	WagerSum             int32           `protobuf:"varint,4,opt,name=wager_sum,json=wagerSum,proto3" json:"wager_sum,omitempty"`
+	CurrentHand          *CurrentHand    `protobuf:"bytes,5,opt,name=current_hand,json=currentHand,proto3" json:"current_hand,omitempty"`
+	Tricks               []*Trick        `protobuf:"bytes,6,rep,name=tricks,proto3" json:"tricks,omitempty"`
+	PreviousHand         *PreviousHand   `protobuf:"bytes,7,opt,name=previous_hand,json=previousHand,proto3" json:"previous_hand,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *Status) Reset()         { *m = Status{} }
+func (m *Status) String() string { return proto.CompactTextString(m) }
+func (*Status) ProtoMessage()    {}
+func (*Status) Descriptor() ([]byte, []int) {
+	return fileDescriptor_38fc58335341d769, []int{15}
+}
+
+func (m *Status) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Status.Unmarshal(m, b)
+}
+func (m *Status) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Status.Marshal(b, m, deterministic)
+}
+func (m *Status) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Status.Merge(m, src)
+}
+func (m *Status) XXX_Size() int {
+	return xxx_messageInfo_Status.Size(m)
+}
+func (m *Status) XXX_DiscardUnknown() {
+	xxx_messageInfo_Status.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Status proto.InternalMessageInfo
+
+func (m *Status) GetPlayerStatuses() []*PlayerStatus {
+	if m != nil {
+		return m.PlayerStatuses
+	}
+	return nil
+}
+
+func (m *Status) GetTrumpSuit() string {
+	if m != nil {
+		return m.TrumpSuit
+	}
+	return ""
+}
+
+func (m *Status) GetNextWagerPlayer() string {
+	if m != nil {
+		return m.NextWagerPlayer
+	}
+	return ""
+}
+
+func (m *Status) GetWagerSum() int32 {
+	if m != nil {
+		return m.WagerSum
+	}
+	return 0
+}
+
+func (m *Status) GetCurrentHand() *CurrentHand {
+	if m != nil {
+		return m.CurrentHand
+	}
+	return nil
+}
+
+func (m *Status) GetTricks() []*Trick {
+	if m != nil {
+		return m.Tricks
+	}
+	return nil
+}
+
+func (m *Status) GetPreviousHand() *PreviousHand {
+	if m != nil {
+		return m.PreviousHand
+	}
+	return nil
+}
+
+type PlayerModel struct {
+	Version     int32       `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Me          string      `protobuf:"bytes,2,opt,name=me,proto3" json:"me,omitempty"`
+	State       string      `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	Game        *PlayerGame `protobuf:"bytes,4,opt,name=game,proto3" json:"game,omitempty"`
+	Status      *Status     `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	MyCards     []*Card     `protobuf:"bytes,6,rep,name=my_cards,json=myCards,proto3" json:"my_cards,omitempty"`
+	LegalWagers []int32     `protobuf:"varint,7,rep,packed,name=legal_wagers,json=legalWagers,proto3" json:"legal_wagers,omitempty"`
+	LegalCards  []*Card     `protobuf:"bytes,8,rep,name=legal_cards,json=legalCards,proto3" json:"legal_cards,omitempty"`
+	// a message from the admins, shown at every table
+	Announcement         string   `protobuf:"bytes,9,opt,name=announcement,proto3" json:"announcement,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PlayerModel) Reset()         { *m = PlayerModel{} }
+func (m *PlayerModel) String() string { return proto.CompactTextString(m) }
+func (*PlayerModel) ProtoMessage()    {}
+func (*PlayerModel) Descriptor() ([]byte, []int) {
+	return fileDescriptor_38fc58335341d769, []int{16}
+}
+
+func (m *PlayerModel) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PlayerModel.Unmarshal(m, b)
+}
+func (m *PlayerModel) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PlayerModel.Marshal(b, m, deterministic)
+}
+func (m *PlayerModel) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlayerModel.Merge(m, src)
+}
+func (m *PlayerModel) XXX_Size() int {
+	return xxx_messageInfo_PlayerModel.Size(m)
+}
+func (m *PlayerModel) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlayerModel.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlayerModel proto.InternalMessageInfo
+
+func (m *PlayerModel) GetVersion() int32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *PlayerModel) GetMe() string {
+	if m != nil {
+		return m.Me
+	}
+	return ""
+}
+
+func (m *PlayerModel) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *PlayerModel) GetGame() *PlayerGame {
+	if m != nil {
+		return m.Game
+	}
+	return nil
+}
+
+func (m *PlayerModel) GetStatus() *Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *PlayerModel) GetMyCards() []*Card {
+	if m != nil {
+		return m.MyCards
+	}
+	return nil
+}
+
+func (m *PlayerModel) GetLegalWagers() []int32 {
+	if m != nil {
+		return m.LegalWagers
+	}
+	return nil
+}
+
+func (m *PlayerModel) GetLegalCards() []*Card {
+	if m != nil {
+		return m.LegalCards
+	}
+	return nil
+}
+
+func (m *PlayerModel) GetAnnouncement() string {
+	if m != nil {
+		return m.Announcement
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*ActionOptions)(nil), "upanddowntheriver.ActionOptions")
+	proto.RegisterType((*PlayerRequest)(nil), "upanddowntheriver.PlayerRequest")
+	proto.RegisterType((*JoinRequest)(nil), "upanddowntheriver.JoinRequest")
+	proto.RegisterType((*RemovePlayerRequest)(nil), "upanddowntheriver.RemovePlayerRequest")
+	proto.RegisterType((*SetCardsPerPlayerRequest)(nil), "upanddowntheriver.SetCardsPerPlayerRequest")
+	proto.RegisterType((*SetDeckTypeRequest)(nil), "upanddowntheriver.SetDeckTypeRequest")
+	proto.RegisterType((*MakeWagerRequest)(nil), "upanddowntheriver.MakeWagerRequest")
+	proto.RegisterType((*PlayCardRequest)(nil), "upanddowntheriver.PlayCardRequest")
+	proto.RegisterType((*Card)(nil), "upanddowntheriver.Card")
+	proto.RegisterType((*CardPlay)(nil), "upanddowntheriver.CardPlay")
+	proto.RegisterType((*PlayerGame)(nil), "upanddowntheriver.PlayerGame")
+	proto.RegisterType((*PlayerStatus)(nil), "upanddowntheriver.PlayerStatus")
+	proto.RegisterType((*CurrentHand)(nil), "upanddowntheriver.CurrentHand")
+	proto.RegisterType((*PreviousHand)(nil), "upanddowntheriver.PreviousHand")
+	proto.RegisterType((*Trick)(nil), "upanddowntheriver.Trick")
+	proto.RegisterType((*Status)(nil), "upanddowntheriver.Status")
+	proto.RegisterType((*PlayerModel)(nil), "upanddowntheriver.PlayerModel")
+}
+
+func init() { proto.RegisterFile("game.proto", fileDescriptor_38fc58335341d769) }
+
+var fileDescriptor_38fc58335341d769 = []byte{
+	// 1250 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0xcb, 0x6e, 0xdb, 0x46,
+	0x17, 0x86, 0x2e, 0xd4, 0xe5, 0x50, 0xb6, 0xec, 0x49, 0xf0, 0xff, 0x6c, 0x82, 0x24, 0x2a, 0xdb,
+	0xa6, 0x46, 0x52, 0x48, 0xb1, 0x82, 0x02, 0x41, 0xd0, 0x4d, 0x9a, 0x20, 0x49, 0x2f, 0x49, 0x8c,
+	0xb1, 0x6b, 0x03, 0xed, 0x82, 0xa5, 0xc9, 0x13, 0x99, 0x90, 0x38, 0x64, 0x39, 0x43, 0xcb, 0x02,
+	0x5a, 0xa0, 0xe8, 0xb2, 0x8f, 0xd2, 0x65, 0x5f, 0xa2, 0x6f, 0xd1, 0x75, 0x5f, 0xa0, 0xfb, 0x62,
+	0x66, 0xc8, 0x88, 0xb6, 0x49, 0x39, 0x81, 0xb3, 0x11, 0x78, 0x46, 0xdf, 0xf9, 0xce, 0x7d, 0xe6,
+	0x00, 0x4c, 0xdc, 0x10, 0x87, 0x71, 0x12, 0x89, 0x88, 0x6c, 0xa6, 0xb1, 0xcb, 0x7c, 0x3f, 0x9a,
+	0x33, 0x71, 0x84, 0x49, 0x70, 0x8c, 0xc9, 0xb5, 0x9b, 0x93, 0x28, 0x9a, 0xcc, 0x70, 0xa4, 0x00,
+	0x87, 0xe9, 0xeb, 0xd1, 0x3c, 0x71, 0xe3, 0x18, 0x13, 0xae, 0x55, 0xec, 0x5f, 0x6b, 0xb0, 0xf6,
+	0xc8, 0x13, 0x41, 0xc4, 0x5e, 0xc5, 0xf2, 0x97, 0x93, 0xa7, 0xb0, 0x81, 0x27, 0x31, 0x7a, 0x02,
+	0x7d, 0xe7, 0x18, 0x13, 0x1e, 0x44, 0xcc, 0xaa, 0x0d, 0x6a, 0x5b, 0xe6, 0xf8, 0xfa, 0x50, 0x93,
+	0x0d, 0x73, 0xb2, 0xe1, 0x57, 0x4c, 0xdc, 0x1f, 0xef, 0xbb, 0xb3, 0x14, 0x69, 0x3f, 0x57, 0xda,
+	0xd7, 0x3a, 0xe4, 0x53, 0xe8, 0x07, 0x3e, 0x86, 0x71, 0x24, 0x90, 0x79, 0x0b, 0x67, 0x8a, 0x0b,
+	0xab, 0x3e, 0xa8, 0x6d, 0x75, 0xe9, 0x7a, 0xe1, 0xf8, 0x1b, 0x5c, 0xd8, 0x3f, 0xc0, 0xda, 0xce,
+	0xcc, 0x5d, 0x60, 0x42, 0xf1, 0xa7, 0x14, 0xb9, 0x20, 0xeb, 0x50, 0x0f, 0x51, 0xd9, 0xec, 0xd2,
+	0x7a, 0x88, 0xe4, 0x21, 0xb4, 0x23, 0xed, 0x9c, 0x62, 0x30, 0xc7, 0x83, 0xe1, 0xb9, 0x40, 0x87,
+	0xa7, 0x82, 0xa0, 0xb9, 0x82, 0x7d, 0x03, 0xcc, 0xaf, 0xa3, 0x80, 0x55, 0x50, 0xdb, 0x0b, 0xb8,
+	0x42, 0x31, 0x8c, 0x8e, 0x71, 0xb5, 0x07, 0xff, 0x83, 0x56, 0xac, 0x00, 0x59, 0x08, 0x99, 0x54,
+	0xf4, 0xac, 0xf1, 0xae, 0x9e, 0xfd, 0x0c, 0xd6, 0x2e, 0x8a, 0xc7, 0x6e, 0xe2, 0xf3, 0x1d, 0x4c,
+	0x56, 0xdb, 0xbf, 0x0a, 0x86, 0x17, 0xa5, 0x4c, 0x28, 0xf3, 0x06, 0xd5, 0xc2, 0xa5, 0xac, 0xff,
+	0x02, 0x64, 0x17, 0xc5, 0x13, 0xf4, 0xa6, 0x7b, 0x8b, 0x18, 0xab, 0xec, 0x5e, 0x87, 0xae, 0x8f,
+	0xde, 0xd4, 0x11, 0x8b, 0x18, 0xb3, 0xd0, 0x3b, 0x7e, 0xa6, 0x73, 0x29, 0xf3, 0x02, 0x36, 0x5e,
+	0xb8, 0x53, 0x3c, 0x70, 0x27, 0x2b, 0x83, 0x3e, 0x72, 0x99, 0xcf, 0xf3, 0xa0, 0x95, 0x70, 0x29,
+	0xab, 0xbf, 0xd7, 0xa0, 0x2f, 0x13, 0x2d, 0x93, 0x5e, 0x65, 0xf5, 0x2e, 0x34, 0x3d, 0x37, 0xf1,
+	0xb3, 0x4e, 0xfb, 0x7f, 0x09, 0xb9, 0xd2, 0x56, 0xa0, 0x4b, 0x39, 0x33, 0x86, 0xa6, 0x64, 0x22,
+	0x04, 0x9a, 0x3c, 0x0d, 0x44, 0xe6, 0x82, 0xfa, 0x96, 0xfd, 0xc6, 0xd2, 0xf0, 0x70, 0xd9, 0x6f,
+	0x5a, 0xb2, 0x5f, 0x41, 0x47, 0xea, 0xc8, 0x18, 0x0a, 0x3d, 0x59, 0x3b, 0xd5, 0x93, 0xef, 0x12,
+	0x80, 0xfd, 0x67, 0x0d, 0x40, 0xb7, 0xde, 0x33, 0x37, 0x44, 0x62, 0x41, 0x5b, 0xb3, 0x70, 0xab,
+	0x36, 0x68, 0x6c, 0x75, 0x69, 0x2e, 0x92, 0x11, 0x5c, 0x0d, 0xdd, 0x13, 0x47, 0x2a, 0x71, 0x27,
+	0xc6, 0xc4, 0x29, 0xcc, 0x83, 0x41, 0x37, 0x43, 0xf7, 0xe4, 0x74, 0x27, 0x93, 0x2d, 0xd8, 0x38,
+	0x07, 0x6e, 0x28, 0xf0, 0xba, 0x77, 0x1a, 0x79, 0xaa, 0xc9, 0x9a, 0x67, 0x9a, 0x8c, 0x40, 0x73,
+	0x92, 0x06, 0xbe, 0x65, 0xe8, 0xec, 0xc8, 0x6f, 0xfb, 0xef, 0x06, 0xf4, 0xb4, 0xee, 0xae, 0x70,
+	0x45, 0xca, 0x2b, 0x53, 0x71, 0x05, 0x8c, 0x80, 0x3b, 0xa1, 0x6e, 0xdd, 0x0e, 0x6d, 0x06, 0xfc,
+	0x05, 0x92, 0xdb, 0xd0, 0x0f, 0xb8, 0xc3, 0xf0, 0x44, 0x38, 0x73, 0xd9, 0x7e, 0x99, 0x5f, 0x1d,
+	0xba, 0x16, 0xf0, 0x97, 0x78, 0x22, 0x0e, 0xf4, 0x21, 0xf9, 0x18, 0xd6, 0x73, 0x5c, 0x46, 0xde,
+	0x54, 0xb0, 0x9e, 0x86, 0x65, 0xce, 0xdf, 0x81, 0xcd, 0x80, 0x3b, 0x5e, 0x9a, 0x24, 0xc8, 0x84,
+	0x33, 0x43, 0xd7, 0xc7, 0x44, 0x39, 0xdb, 0xa1, 0xfd, 0x80, 0x3f, 0xd6, 0xe7, 0xdf, 0xaa, 0x63,
+	0xf2, 0x19, 0x90, 0x80, 0x3b, 0x71, 0x82, 0xc7, 0x41, 0x94, 0x72, 0x67, 0x1e, 0x30, 0x86, 0x89,
+	0xd5, 0x52, 0xe0, 0x8d, 0x80, 0xef, 0x64, 0x7f, 0x1c, 0xa8, 0x73, 0x19, 0x79, 0x18, 0x45, 0xbe,
+	0xd5, 0xd6, 0x91, 0xcb, 0x6f, 0xb2, 0x0d, 0x86, 0xf2, 0xd9, 0xea, 0x5c, 0x7c, 0x21, 0x6b, 0x24,
+	0x79, 0x00, 0x5d, 0x35, 0x38, 0xce, 0x3c, 0x62, 0x56, 0xf7, 0x62, 0xb5, 0x8e, 0x42, 0x1f, 0x44,
+	0x8c, 0x7c, 0x01, 0x6b, 0x6f, 0x7c, 0x55, 0x1d, 0x05, 0xab, 0x3b, 0xaa, 0x97, 0xa3, 0x1f, 0xeb,
+	0xd1, 0xe8, 0xe5, 0x59, 0x51, 0xca, 0xe6, 0x6a, 0x65, 0x33, 0x03, 0x4b, 0xc1, 0xfe, 0xab, 0x06,
+	0x66, 0x96, 0xba, 0xe7, 0x2e, 0xab, 0x1c, 0x91, 0x2c, 0xdb, 0xd9, 0x88, 0x68, 0x89, 0x3c, 0x00,
+	0x53, 0x7f, 0x69, 0xb3, 0x8d, 0xd5, 0x66, 0x41, 0x63, 0x95, 0xc7, 0xb7, 0xc0, 0x3c, 0x5b, 0xed,
+	0x2e, 0x05, 0xb6, 0xac, 0xf5, 0x36, 0x18, 0xf2, 0x3f, 0x6e, 0x19, 0x83, 0x86, 0x4a, 0x63, 0x39,
+	0xa9, 0x44, 0x53, 0x8d, 0xb4, 0x1f, 0x42, 0x2f, 0x2f, 0xeb, 0xaa, 0x48, 0xb2, 0x56, 0xc8, 0x22,
+	0xd1, 0x92, 0xfd, 0x5b, 0x0d, 0x8c, 0xbd, 0x24, 0xf0, 0xa6, 0xa5, 0x5a, 0xdb, 0x60, 0xa8, 0x39,
+	0xb2, 0xea, 0x6f, 0xe1, 0x8c, 0x42, 0x16, 0x0c, 0x35, 0x8a, 0x86, 0xc8, 0x35, 0xe8, 0xb0, 0x48,
+	0xb8, 0xf2, 0x5a, 0xca, 0xe7, 0x2f, 0x97, 0xed, 0x7f, 0xeb, 0xd0, 0xca, 0xa6, 0xec, 0x39, 0xf4,
+	0x75, 0x6a, 0x1c, 0xae, 0x0e, 0x50, 0x5f, 0x12, 0xe6, 0xf8, 0x56, 0x89, 0xed, 0xe2, 0x7c, 0xd2,
+	0xf5, 0xb8, 0x20, 0x21, 0x27, 0x37, 0x00, 0x44, 0x92, 0x86, 0xb1, 0xa3, 0xa2, 0xd2, 0x51, 0x77,
+	0xd5, 0xc9, 0xae, 0x0c, 0xed, 0x0e, 0x6c, 0x2e, 0xc7, 0xb3, 0x78, 0x77, 0x74, 0x69, 0x9f, 0xe5,
+	0x13, 0xba, 0xbc, 0x3c, 0x34, 0x8c, 0xa7, 0xa1, 0x72, 0xde, 0xa0, 0x1d, 0x75, 0xb0, 0x9b, 0x86,
+	0xe4, 0xd1, 0xb2, 0x07, 0x65, 0x57, 0xab, 0xb9, 0x34, 0xc7, 0x37, 0xcb, 0x52, 0xb5, 0xec, 0xb6,
+	0x37, 0xad, 0x28, 0x05, 0x72, 0x0f, 0x5a, 0x42, 0xd6, 0x80, 0x5b, 0x2d, 0x15, 0xab, 0x55, 0xa2,
+	0xac, 0x8a, 0x44, 0x33, 0x1c, 0x79, 0x52, 0x18, 0x1b, 0x65, 0xb5, 0x3d, 0xa8, 0x55, 0x25, 0xa9,
+	0xd0, 0x1a, 0xcb, 0xf1, 0x91, 0x92, 0xfd, 0x4f, 0x1d, 0x4c, 0x1d, 0xe2, 0x8b, 0xc8, 0xc7, 0x99,
+	0xbc, 0x99, 0x8b, 0xcb, 0x98, 0x41, 0x73, 0x31, 0x7b, 0xc0, 0xea, 0xc5, 0x67, 0x53, 0xd6, 0x07,
+	0xb3, 0x8c, 0x69, 0x81, 0x6c, 0x43, 0x53, 0x2e, 0x8a, 0x2a, 0x45, 0xe6, 0xf8, 0x46, 0x65, 0xc5,
+	0xe4, 0x33, 0x40, 0x15, 0x94, 0x6c, 0x43, 0x4b, 0x17, 0x3a, 0xcb, 0xdb, 0x07, 0x25, 0x4a, 0x59,
+	0x81, 0x33, 0x20, 0x19, 0x43, 0x27, 0x5c, 0xe8, 0x47, 0x22, 0xcb, 0x57, 0xe5, 0xe4, 0xb5, 0x43,
+	0xf5, 0x0c, 0x73, 0xf2, 0x21, 0xf4, 0x66, 0x38, 0x71, 0x67, 0xba, 0xdc, 0xdc, 0x6a, 0x0f, 0x1a,
+	0x5b, 0x06, 0x35, 0xd5, 0x99, 0xaa, 0x34, 0xd7, 0x33, 0x2d, 0x21, 0x9a, 0xb9, 0xb3, 0x9a, 0x19,
+	0x14, 0x56, 0x93, 0xdb, 0xd0, 0x73, 0x19, 0x8b, 0x52, 0xe6, 0x61, 0x88, 0x4c, 0xa8, 0x0b, 0xb0,
+	0x4b, 0x4f, 0x9d, 0x8d, 0xff, 0x68, 0xc3, 0xe6, 0x77, 0xf1, 0x23, 0xe6, 0x3f, 0x89, 0xe6, 0x6c,
+	0xef, 0x08, 0xa9, 0xa4, 0x22, 0x4f, 0xa1, 0x29, 0x17, 0x47, 0x52, 0xd6, 0x2d, 0x85, 0x8d, 0xf2,
+	0xda, 0xcd, 0xca, 0x54, 0xea, 0xc2, 0xed, 0x43, 0xaf, 0xb8, 0x61, 0x92, 0xdb, 0x25, 0xf8, 0x92,
+	0x15, 0xf4, 0x42, 0xde, 0x1f, 0x61, 0xf3, 0xdc, 0xfa, 0x48, 0xee, 0x96, 0x95, 0xa8, 0x62, 0xc9,
+	0xbc, 0xd0, 0xc2, 0x1e, 0x98, 0x85, 0x15, 0x91, 0x7c, 0x52, 0xce, 0x7d, 0x66, 0x85, 0xbc, 0x90,
+	0xf5, 0x25, 0xc0, 0xae, 0x70, 0x13, 0x41, 0xa3, 0x94, 0xf9, 0x64, 0x50, 0x89, 0x7e, 0x5b, 0xbe,
+	0x1d, 0xe8, 0xbe, 0xd9, 0x24, 0xc9, 0x47, 0x25, 0xe0, 0xb3, 0x7b, 0xe6, 0x5b, 0x78, 0xd8, 0xc9,
+	0x97, 0x44, 0x62, 0x57, 0x60, 0x0b, 0x1b, 0xe4, 0x85, 0x7c, 0xaf, 0xc0, 0x7c, 0x1a, 0xb0, 0x80,
+	0x1f, 0xbd, 0xaf, 0x90, 0x5f, 0x02, 0x68, 0x42, 0xb5, 0xb3, 0x5d, 0x9e, 0x8f, 0xc2, 0xfa, 0x33,
+	0x14, 0xc5, 0x93, 0xcb, 0x73, 0xee, 0xc3, 0xc6, 0x81, 0x2b, 0xbc, 0xa3, 0xf7, 0xca, 0x7a, 0xaf,
+	0xf6, 0xe5, 0xe7, 0xdf, 0xdf, 0x9f, 0x04, 0xe2, 0x28, 0x3d, 0x1c, 0x7a, 0x51, 0x38, 0x0a, 0x5d,
+	0x21, 0x5e, 0x23, 0x9b, 0x07, 0xde, 0x74, 0x74, 0x4e, 0x73, 0x14, 0x4f, 0x27, 0x23, 0x79, 0x93,
+	0xc5, 0x87, 0x87, 0x2d, 0xb5, 0xea, 0xdc, 0xff, 0x6f, 0x00, 0xbe, 0xa1, 0xc5, 0x63, 0x2e, 0x0f,
+	0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// UpAndDownTheRiverClient is the client API for UpAndDownTheRiver service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type UpAndDownTheRiverClient interface {
+	Join(ctx context.Context, in *JoinRequest, opts ...grpc.CallOption) (*PlayerModel, error)
+	RemovePlayer(ctx context.Context, in *RemovePlayerRequest, opts ...grpc.CallOption) (*PlayerModel, error)
+	SetCardsPerPlayer(ctx context.Context, in *SetCardsPerPlayerRequest, opts ...grpc.CallOption) (*PlayerModel, error)
+	SetDeckType(ctx context.Context, in *SetDeckTypeRequest, opts ...grpc.CallOption) (*PlayerModel, error)
+	StartRound(ctx context.Context, in *PlayerRequest, opts ...grpc.CallOption) (*PlayerModel, error)
+	MakeWager(ctx context.Context, in *MakeWagerRequest, opts ...grpc.CallOption) (*PlayerModel, error)
+	PlayCard(ctx context.Context, in *PlayCardRequest, opts ...grpc.CallOption) (*PlayerModel, error)
+	FinishRound(ctx context.Context, in *PlayerRequest, opts ...grpc.CallOption) (*PlayerModel, error)
+	FinishGame(ctx context.Context, in *PlayerRequest, opts ...grpc.CallOption) (*PlayerModel, error)
+	GetPlayerModel(ctx context.Context, in *PlayerRequest, opts ...grpc.CallOption) (*PlayerModel, error)
+	// WatchPlayerModel sends the player's current model, and then a new one whenever the game changes
+	WatchPlayerModel(ctx context.Context, in *PlayerRequest, opts ...grpc.CallOption) (UpAndDownTheRiver_WatchPlayerModelClient, error)
+}
+
+type upAndDownTheRiverClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewUpAndDownTheRiverClient(cc *grpc.ClientConn) UpAndDownTheRiverClient {
+	return &upAndDownTheRiverClient{cc}
+}
+
+func (c *upAndDownTheRiverClient) Join(ctx context.Context, in *JoinRequest, opts ...grpc.CallOption) (*PlayerModel, error) {
+	out := new(PlayerModel)
+	err := c.cc.Invoke(ctx, "/upanddowntheriver.UpAndDownTheRiver/Join", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *upAndDownTheRiverClient) RemovePlayer(ctx context.Context, in *RemovePlayerRequest, opts ...grpc.CallOption) (*PlayerModel, error) {
+	out := new(PlayerModel)
+	err := c.cc.Invoke(ctx, "/upanddowntheriver.UpAndDownTheRiver/RemovePlayer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *upAndDownTheRiverClient) SetCardsPerPlayer(ctx context.Context, in *SetCardsPerPlayerRequest, opts ...grpc.CallOption) (*PlayerModel, error) {
+	out := new(PlayerModel)
+	err := c.cc.Invoke(ctx, "/upanddowntheriver.UpAndDownTheRiver/SetCardsPerPlayer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *upAndDownTheRiverClient) SetDeckType(ctx context.Context, in *SetDeckTypeRequest, opts ...grpc.CallOption) (*PlayerModel, error) {
+	out := new(PlayerModel)
+	err := c.cc.Invoke(ctx, "/upanddowntheriver.UpAndDownTheRiver/SetDeckType", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *upAndDownTheRiverClient) StartRound(ctx context.Context, in *PlayerRequest, opts ...grpc.CallOption) (*PlayerModel, error) {
+	out := new(PlayerModel)
+	err := c.cc.Invoke(ctx, "/upanddowntheriver.UpAndDownTheRiver/StartRound", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *upAndDownTheRiverClient) MakeWager(ctx context.Context, in *MakeWagerRequest, opts ...grpc.CallOption) (*PlayerModel, error) {
+	out := new(PlayerModel)
+	err := c.cc.Invoke(ctx, "/upanddowntheriver.UpAndDownTheRiver/MakeWager", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *upAndDownTheRiverClient) PlayCard(ctx context.Context, in *PlayCardRequest, opts ...grpc.CallOption) (*PlayerModel, error) {
+	out := new(PlayerModel)
+	err := c.cc.Invoke(ctx, "/upanddowntheriver.UpAndDownTheRiver/PlayCard", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *upAndDownTheRiverClient) FinishRound(ctx context.Context, in *PlayerRequest, opts ...grpc.CallOption) (*PlayerModel, error) {
+	out := new(PlayerModel)
+	err := c.cc.Invoke(ctx, "/upanddowntheriver.UpAndDownTheRiver/FinishRound", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *upAndDownTheRiverClient) FinishGame(ctx context.Context, in *PlayerRequest, opts ...grpc.CallOption) (*PlayerModel, error) {
+	out := new(PlayerModel)
+	err := c.cc.Invoke(ctx, "/upanddowntheriver.UpAndDownTheRiver/FinishGame", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *upAndDownTheRiverClient) GetPlayerModel(ctx context.Context, in *PlayerRequest, opts ...grpc.CallOption) (*PlayerModel, error) {
+	out := new(PlayerModel)
+	err := c.cc.Invoke(ctx, "/upanddowntheriver.UpAndDownTheRiver/GetPlayerModel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *upAndDownTheRiverClient) WatchPlayerModel(ctx context.Context, in *PlayerRequest, opts ...grpc.CallOption) (UpAndDownTheRiver_WatchPlayerModelClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_UpAndDownTheRiver_serviceDesc.Streams[0], "/upanddowntheriver.UpAndDownTheRiver/WatchPlayerModel", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &upAndDownTheRiverWatchPlayerModelClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type UpAndDownTheRiver_WatchPlayerModelClient interface {
+	Recv() (*PlayerModel, error)
+	grpc.ClientStream
+}
+
+type upAndDownTheRiverWatchPlayerModelClient struct {
+	grpc.ClientStream
+}
+
+func (x *upAndDownTheRiverWatchPlayerModelClient) Recv() (*PlayerModel, error) {
+	m := new(PlayerModel)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// UpAndDownTheRiverServer is the server API for UpAndDownTheRiver service.
+type UpAndDownTheRiverServer interface {
+	Join(context.Context, *JoinRequest) (*PlayerModel, error)
+	RemovePlayer(context.Context, *RemovePlayerRequest) (*PlayerModel, error)
+	SetCardsPerPlayer(context.Context, *SetCardsPerPlayerRequest) (*PlayerModel, error)
+	SetDeckType(context.Context, *SetDeckTypeRequest) (*PlayerModel, error)
+	StartRound(context.Context, *PlayerRequest) (*PlayerModel, error)
+	MakeWager(context.Context, *MakeWagerRequest) (*PlayerModel, error)
+	PlayCard(context.Context, *PlayCardRequest) (*PlayerModel, error)
+	FinishRound(context.Context, *PlayerRequest) (*PlayerModel, error)
+	FinishGame(context.Context, *PlayerRequest) (*PlayerModel, error)
+	GetPlayerModel(context.Context, *PlayerRequest) (*PlayerModel, error)
+	// WatchPlayerModel sends the player's current model, and then a new one whenever the game changes
+	WatchPlayerModel(*PlayerRequest, UpAndDownTheRiver_WatchPlayerModelServer) error
+}
+
+// UnimplementedUpAndDownTheRiverServer can be embedded to have forward compatible implementations.
+type UnimplementedUpAndDownTheRiverServer struct {
+}
+
+func (*UnimplementedUpAndDownTheRiverServer) Join(ctx context.Context, req *JoinRequest) (*PlayerModel, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Join not implemented")
+}
+func (*UnimplementedUpAndDownTheRiverServer) RemovePlayer(ctx context.Context, req *RemovePlayerRequest) (*PlayerModel, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemovePlayer not implemented")
+}
+func (*UnimplementedUpAndDownTheRiverServer) SetCardsPerPlayer(ctx context.Context, req *SetCardsPerPlayerRequest) (*PlayerModel, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetCardsPerPlayer not implemented")
+}
+func (*UnimplementedUpAndDownTheRiverServer) SetDeckType(ctx context.Context, req *SetDeckTypeRequest) (*PlayerModel, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetDeckType not implemented")
+}
+func (*UnimplementedUpAndDownTheRiverServer) StartRound(ctx context.Context, req *PlayerRequest) (*PlayerModel, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartRound not implemented")
+}
+func (*UnimplementedUpAndDownTheRiverServer) MakeWager(ctx context.Context, req *MakeWagerRequest) (*PlayerModel, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MakeWager not implemented")
+}
+func (*UnimplementedUpAndDownTheRiverServer) PlayCard(ctx context.Context, req *PlayCardRequest) (*PlayerModel, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlayCard not implemented")
+}
+func (*UnimplementedUpAndDownTheRiverServer) FinishRound(ctx context.Context, req *PlayerRequest) (*PlayerModel, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FinishRound not implemented")
+}
+func (*UnimplementedUpAndDownTheRiverServer) FinishGame(ctx context.Context, req *PlayerRequest) (*PlayerModel, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FinishGame not implemented")
+}
+func (*UnimplementedUpAndDownTheRiverServer) GetPlayerModel(ctx context.Context, req *PlayerRequest) (*PlayerModel, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPlayerModel not implemented")
+}
+func (*UnimplementedUpAndDownTheRiverServer) WatchPlayerModel(req *PlayerRequest, srv UpAndDownTheRiver_WatchPlayerModelServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchPlayerModel not implemented")
+}
+
+func RegisterUpAndDownTheRiverServer(s *grpc.Server, srv UpAndDownTheRiverServer) {
+	s.RegisterService(&_UpAndDownTheRiver_serviceDesc, srv)
+}
+
+func _UpAndDownTheRiver_Join_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UpAndDownTheRiverServer).Join(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/upanddowntheriver.UpAndDownTheRiver/Join",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UpAndDownTheRiverServer).Join(ctx, req.(*JoinRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UpAndDownTheRiver_RemovePlayer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemovePlayerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UpAndDownTheRiverServer).RemovePlayer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/upanddowntheriver.UpAndDownTheRiver/RemovePlayer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UpAndDownTheRiverServer).RemovePlayer(ctx, req.(*RemovePlayerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UpAndDownTheRiver_SetCardsPerPlayer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetCardsPerPlayerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UpAndDownTheRiverServer).SetCardsPerPlayer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/upanddowntheriver.UpAndDownTheRiver/SetCardsPerPlayer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UpAndDownTheRiverServer).SetCardsPerPlayer(ctx, req.(*SetCardsPerPlayerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UpAndDownTheRiver_SetDeckType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetDeckTypeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UpAndDownTheRiverServer).SetDeckType(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/upanddowntheriver.UpAndDownTheRiver/SetDeckType",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UpAndDownTheRiverServer).SetDeckType(ctx, req.(*SetDeckTypeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UpAndDownTheRiver_StartRound_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlayerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UpAndDownTheRiverServer).StartRound(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/upanddowntheriver.UpAndDownTheRiver/StartRound",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UpAndDownTheRiverServer).StartRound(ctx, req.(*PlayerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UpAndDownTheRiver_MakeWager_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MakeWagerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UpAndDownTheRiverServer).MakeWager(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/upanddowntheriver.UpAndDownTheRiver/MakeWager",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UpAndDownTheRiverServer).MakeWager(ctx, req.(*MakeWagerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UpAndDownTheRiver_PlayCard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlayCardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UpAndDownTheRiverServer).PlayCard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/upanddowntheriver.UpAndDownTheRiver/PlayCard",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UpAndDownTheRiverServer).PlayCard(ctx, req.(*PlayCardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UpAndDownTheRiver_FinishRound_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlayerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UpAndDownTheRiverServer).FinishRound(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/upanddowntheriver.UpAndDownTheRiver/FinishRound",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UpAndDownTheRiverServer).FinishRound(ctx, req.(*PlayerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UpAndDownTheRiver_FinishGame_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlayerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UpAndDownTheRiverServer).FinishGame(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/upanddowntheriver.UpAndDownTheRiver/FinishGame",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UpAndDownTheRiverServer).FinishGame(ctx, req.(*PlayerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UpAndDownTheRiver_GetPlayerModel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlayerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UpAndDownTheRiverServer).GetPlayerModel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/upanddowntheriver.UpAndDownTheRiver/GetPlayerModel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UpAndDownTheRiverServer).GetPlayerModel(ctx, req.(*PlayerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UpAndDownTheRiver_WatchPlayerModel_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PlayerRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UpAndDownTheRiverServer).WatchPlayerModel(m, &upAndDownTheRiverWatchPlayerModelServer{stream})
+}
+
+type UpAndDownTheRiver_WatchPlayerModelServer interface {
+	Send(*PlayerModel) error
+	grpc.ServerStream
+}
+
+type upAndDownTheRiverWatchPlayerModelServer struct {
+	grpc.ServerStream
+}
+
+func (x *upAndDownTheRiverWatchPlayerModelServer) Send(m *PlayerModel) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _UpAndDownTheRiver_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "upanddowntheriver.UpAndDownTheRiver",
+	HandlerType: (*UpAndDownTheRiverServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Join",
+			Handler:    _UpAndDownTheRiver_Join_Handler,
+		},
+		{
+			MethodName: "RemovePlayer",
+			Handler:    _UpAndDownTheRiver_RemovePlayer_Handler,
+		},
+		{
+			MethodName: "SetCardsPerPlayer",
+			Handler:    _UpAndDownTheRiver_SetCardsPerPlayer_Handler,
+		},
+		{
+			MethodName: "SetDeckType",
+			Handler:    _UpAndDownTheRiver_SetDeckType_Handler,
+		},
+		{
+			MethodName: "StartRound",
+			Handler:    _UpAndDownTheRiver_StartRound_Handler,
+		},
+		{
+			MethodName: "MakeWager",
+			Handler:    _UpAndDownTheRiver_MakeWager_Handler,
+		},
+		{
+			MethodName: "PlayCard",
+			Handler:    _UpAndDownTheRiver_PlayCard_Handler,
+		},
+		{
+			MethodName: "FinishRound",
+			Handler:    _UpAndDownTheRiver_FinishRound_Handler,
+		},
+		{
+			MethodName: "FinishGame",
+			Handler:    _UpAndDownTheRiver_FinishGame_Handler,
+		},
+		{
+			MethodName: "GetPlayerModel",
+			Handler:    _UpAndDownTheRiver_GetPlayerModel_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchPlayerModel",
+			Handler:       _UpAndDownTheRiver_WatchPlayerModel_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "game.proto",
+}